@@ -0,0 +1,327 @@
+// Package namingtooltest provides an in-memory Naming Tool for the tests of the API client
+// and the provider.
+package namingtooltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// The collections served by Server, by path.
+const (
+	Environments     = "/api/ResourceEnvironments"
+	Functions        = "/api/ResourceFunctions"
+	Locations        = "/api/ResourceLocations"
+	Organizations    = "/api/ResourceOrgs"
+	Projects         = "/api/ResourceProjAppSvcs"
+	Units            = "/api/ResourceUnitDepts"
+	Components       = "/api/ResourceComponents"
+	CustomComponents = "/api/CustomComponents"
+	Delimiters       = "/api/ResourceDelimiters"
+	ResourceTypes    = "/api/ResourceTypes"
+)
+
+var collections = []string{Environments, Functions, Locations, Organizations, Projects, Units, Components, CustomComponents, Delimiters, ResourceTypes}
+
+// Server is an in-memory Naming Tool serving the reference data collections. Each collection
+// lists, gets, creates or updates and deletes its records by ID, and the special endpoints of
+// the custom components and resource types work on the same records. Records are kept as
+// JSON objects, so any model with an "id" property can be stored.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	collections map[string]*collection
+}
+
+type collection struct {
+	records   []map[string]interface{} // In insertion order
+	posted    []json.RawMessage        // The records posted to the collection, in order
+	deleted   []int64                  // The IDs deleted from the collection, in order
+	writes    int                      // The POST and DELETE requests accepted
+	plainText bool                     // Whether posts are answered with a confirmation instead of the record
+	reject    int                      // The status posts are rejected with. Zero accepts them.
+}
+
+// NewServer starts a Naming Tool without records, which is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	s := &Server{collections: map[string]*collection{}}
+	for _, path := range collections {
+		s.collections[path] = &collection{}
+	}
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Put adds records to collection, replacing the records with the same ID.
+func (s *Server) Put(collection string, records ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, record := range records {
+		s.collections[collection].put(toObject(record))
+	}
+}
+
+// PlainTextWrites makes the posts to collection answer with a plain text confirmation instead
+// of the written record, as the Naming Tool does for some writes.
+func (s *Server) PlainTextWrites(collection string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections[collection].plainText = true
+}
+
+// RejectWrites makes the posts to collection fail with status.
+func (s *Server) RejectWrites(collection string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections[collection].reject = status
+}
+
+// Writes returns the number of POST and DELETE requests accepted by collection.
+func (s *Server) Writes(collection string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.collections[collection].writes
+}
+
+// Deleted returns the IDs deleted from collection, in order.
+func (s *Server) Deleted(collection string) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.collections[collection].deleted...)
+}
+
+// Records returns the records of collection decoded as T, in insertion order.
+func Records[T any](s *Server, collection string) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]T, 0, len(s.collections[collection].records))
+	for _, record := range s.collections[collection].records {
+		records = append(records, fromJSON[T](mustMarshal(record)))
+	}
+	return records
+}
+
+// Record returns the record of collection with id decoded as T, and whether it exists.
+func Record[T any](s *Server, collection string, id int64) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.collections[collection].index(id); i >= 0 {
+		return fromJSON[T](mustMarshal(s.collections[collection].records[i])), true
+	}
+	var zero T
+	return zero, false
+}
+
+// Posted returns the records posted to collection decoded as T, in order, with the IDs
+// assigned to new records.
+func Posted[T any](s *Server, collection string) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	posted := make([]T, 0, len(s.collections[collection].posted))
+	for _, record := range s.collections[collection].posted {
+		posted = append(posted, fromJSON[T](record))
+	}
+	return posted
+}
+
+// ServeHTTP serves the collection the path of r belongs to.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for path, c := range s.collections {
+		if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+			rest, _ := url.PathUnescape(strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, path), "/"))
+			s.serveCollection(w, r, c, rest)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// serveCollection serves a request to c, where rest is the unescaped path below the collection.
+// The caller must hold mu.
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, c *collection, rest string) {
+	action, param, _ := strings.Cut(rest, "/")
+	switch {
+	case r.Method == http.MethodGet && rest == "":
+		writeJSON(w, append([]map[string]interface{}{}, c.records...))
+	case r.Method == http.MethodGet && action == "GetByParentType":
+		writeJSON(w, c.children(param))
+	case r.Method == http.MethodGet && action == "GetByParentId":
+		writeJSON(w, c.children(s.componentName(param)))
+	case r.Method == http.MethodGet:
+		id, err := strconv.ParseInt(rest, 10, 64)
+		if i := c.index(id); err == nil && i >= 0 {
+			writeJSON(w, c.records[i])
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodPost && c.reject != 0:
+		w.WriteHeader(c.reject)
+	case r.Method == http.MethodPost && action == "PostConfig":
+		var records []map[string]interface{}
+		if err := decode(r, &records); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.records = records
+		c.writes++
+		w.Write([]byte("Configuration updated!"))
+	case r.Method == http.MethodPost && rest == "":
+		var record map[string]interface{}
+		if err := decode(r, &record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if id(record) == 0 {
+			record["id"] = c.nextID()
+		}
+		c.put(record)
+		c.posted = append(c.posted, mustMarshal(record))
+		c.writes++
+		if c.plainText {
+			w.Write([]byte("Record added!"))
+			return
+		}
+		writeJSON(w, record)
+	case r.Method == http.MethodDelete && action == "DeleteByParentId":
+		parent := s.componentName(param)
+		for _, child := range c.children(parent) {
+			c.delete(id(child))
+		}
+		c.writes++
+		writeJSON(w, "deleted")
+	case r.Method == http.MethodDelete:
+		id, err := strconv.ParseInt(rest, 10, 64)
+		if err != nil || c.index(id) < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		c.delete(id)
+		c.writes++
+		writeJSON(w, "deleted")
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// componentName returns the name of the component with the ID param, or "" if there is none.
+// The caller must hold mu.
+func (s *Server) componentName(param string) string {
+	components := s.collections[Components]
+	id, err := strconv.ParseInt(param, 10, 64)
+	if i := components.index(id); err == nil && i >= 0 {
+		name, _ := components.records[i]["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// put adds record, replacing the record with the same ID.
+func (c *collection) put(record map[string]interface{}) {
+	if i := c.index(id(record)); i >= 0 {
+		c.records[i] = record
+		return
+	}
+	c.records = append(c.records, record)
+}
+
+// delete removes the record with id and logs the deletion.
+func (c *collection) delete(recordID int64) {
+	i := c.index(recordID)
+	c.records = append(c.records[:i], c.records[i+1:]...)
+	c.deleted = append(c.deleted, recordID)
+}
+
+// index returns the position of the record with id, or -1 if there is none.
+func (c *collection) index(recordID int64) int {
+	for i, record := range c.records {
+		if id(record) == recordID {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextID returns an ID greater than the IDs of all records.
+func (c *collection) nextID() int64 {
+	next := int64(1)
+	for _, record := range c.records {
+		if id(record) >= next {
+			next = id(record) + 1
+		}
+	}
+	return next
+}
+
+// children returns the custom component values whose parent component is parent.
+func (c *collection) children(parent string) []map[string]interface{} {
+	children := []map[string]interface{}{}
+	for _, record := range c.records {
+		if record["parentComponent"] == parent && parent != "" {
+			children = append(children, record)
+		}
+	}
+	return children
+}
+
+// id returns the ID of record, or zero if it has none.
+func id(record map[string]interface{}) int64 {
+	switch value := record["id"].(type) {
+	case json.Number:
+		parsed, _ := value.Int64()
+		return parsed
+	case int64:
+		return value
+	}
+	return 0
+}
+
+// decode decodes the JSON body of r into target, keeping the numbers exact.
+func decode(r *http.Request, target interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+// toObject converts a record to a JSON object.
+func toObject(record interface{}) map[string]interface{} {
+	decoder := json.NewDecoder(bytes.NewReader(mustMarshal(record)))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		panic(err)
+	}
+	return object
+}
+
+func fromJSON[T any](data []byte) T {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func mustMarshal(value interface{}) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+)
+
+// entraTokenURL is the token endpoint of an Entra ID tenant.
+const entraTokenURL = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+
+// OAuth2Model describes the oauth2 block of the provider configuration.
+type OAuth2Model struct {
+	TokenUrl     types.String `tfsdk:"token_url"`
+	TenantId     types.String `tfsdk:"tenant_id"`
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+}
+
+// buildAuthenticator creates the authenticator of the API client. The API key and admin
+// password are always sent, and a bearer token is added when bearer_token or oauth2 is set.
+func buildAuthenticator(ctx context.Context, config *AzureNamingToolProviderModel, apiKey string, adminPassword string, httpClient *http.Client) (apiclient.Authenticator, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authenticators := apiclient.MultiAuthenticator{apiclient.NewDefaultAuthenticator(apiKey, adminPassword)}
+
+	bearerToken := os.Getenv("AZ_NAMINGTOOL_BEARER_TOKEN")
+	if !config.BearerToken.IsNull() {
+		bearerToken = config.BearerToken.ValueString()
+	}
+
+	if config.OAuth2 != nil {
+		if bearerToken != "" {
+			diags.AddAttributeError(
+				path.Root("oauth2"),
+				"Conflicting authentication settings",
+				"Only one of bearer_token and oauth2 can be set.",
+			)
+			return nil, diags
+		}
+		authenticator, oauthDiags := config.OAuth2.toAuthenticator(ctx, httpClient)
+		diags.Append(oauthDiags...)
+		authenticators = append(authenticators, authenticator)
+	} else if bearerToken != "" {
+		authenticators = append(authenticators, &apiclient.BearerTokenAuthenticator{Token: bearerToken})
+	}
+
+	return authenticators, diags
+}
+
+// toAuthenticator builds the client credentials authenticator of the oauth2 block.
+func (m *OAuth2Model) toAuthenticator(ctx context.Context, httpClient *http.Client) (*apiclient.ClientCredentialsAuthenticator, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tokenURL := m.TokenUrl.ValueString()
+	switch {
+	case !m.TokenUrl.IsNull() && !m.TenantId.IsNull():
+		diags.AddAttributeError(
+			path.Root("oauth2").AtName("tenant_id"),
+			"Conflicting token endpoint settings",
+			"Only one of token_url and tenant_id can be set.",
+		)
+	case !m.TenantId.IsNull():
+		tokenURL = fmt.Sprintf(entraTokenURL, m.TenantId.ValueString())
+	case m.TokenUrl.IsNull():
+		diags.AddAttributeError(
+			path.Root("oauth2").AtName("token_url"),
+			"Missing token endpoint",
+			"One of token_url and tenant_id must be set.",
+		)
+	}
+
+	clientSecret := os.Getenv("AZ_NAMINGTOOL_CLIENT_SECRET")
+	if !m.ClientSecret.IsNull() {
+		clientSecret = m.ClientSecret.ValueString()
+	}
+	if clientSecret == "" {
+		diags.AddAttributeError(
+			path.Root("oauth2").AtName("client_secret"),
+			"Missing client secret",
+			"The client secret must be set in the configuration or in the AZ_NAMINGTOOL_CLIENT_SECRET environment variable.",
+		)
+	}
+
+	var scopes []string
+	if !m.Scopes.IsNull() {
+		diags.Append(m.Scopes.ElementsAs(ctx, &scopes, false)...)
+	}
+
+	return &apiclient.ClientCredentialsAuthenticator{
+		TokenURL:     tokenURL,
+		ClientID:     m.ClientId.ValueString(),
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		HttpClient:   httpClient,
+	}, diags
+}
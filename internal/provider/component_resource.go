@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &ComponentResource{}
+	_ resource.ResourceWithConfigure      = &ComponentResource{}
+	_ resource.ResourceWithImportState    = &ComponentResource{}
+	_ resource.ResourceWithModifyPlan     = &ComponentResource{}
+	_ resource.ResourceWithValidateConfig = &ComponentResource{}
+)
+
+// NewComponentResource creates the aznamingtool_component resource.
+func NewComponentResource() resource.Resource {
+	return &ComponentResource{}
+}
+
+// ComponentResource manages the settings of a naming component of the Naming Tool.
+type ComponentResource struct {
+	client *apiclient.APIClient
+}
+
+// ComponentResourceModel describes the resource data model.
+type ComponentResourceModel struct {
+	ID                   types.Int64  `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	Enabled              types.Bool   `tfsdk:"enabled"`
+	SortOrder            types.Int64  `tfsdk:"sort_order"`
+	MinLength            types.Int64  `tfsdk:"min_length"`
+	MaxLength            types.Int64  `tfsdk:"max_length"`
+	EnforceRandom        types.Bool   `tfsdk:"enforce_random"`
+	Alphanumeric         types.Bool   `tfsdk:"alphanumeric"`
+	ApplyDelimiterBefore types.Bool   `tfsdk:"apply_delimiter_before"`
+	ApplyDelimiterAfter  types.Bool   `tfsdk:"apply_delimiter_after"`
+}
+
+// Metadata returns the resource type name.
+func (r *ComponentResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "aznamingtool_component"
+}
+
+// Schema defines the schema for the resource.
+func (r *ComponentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	optionalInt64 := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   description,
+			PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+		}
+	}
+	optionalBool := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   description,
+			PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the settings of a naming component of the Naming Tool. Settings left out keep their value in the Naming Tool.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:      true,
+				Description:   "The ID of the component in the Naming Tool.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the component, such as ResourceEnvironment.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"display_name": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "The name of the component shown in the Naming Tool.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"enabled":                optionalBool("Whether generated names include the component."),
+			"sort_order":             optionalInt64("The position of the component in generated names. It must be unique across all components."),
+			"min_length":             optionalInt64("The minimum length of the component value."),
+			"max_length":             optionalInt64("The maximum length of the component value."),
+			"enforce_random":         optionalBool("Whether the component value is generated randomly."),
+			"alphanumeric":           optionalBool("Whether the component value may only contain letters and digits."),
+			"apply_delimiter_before": optionalBool("Whether the delimiter is inserted before the component."),
+			"apply_delimiter_after":  optionalBool("Whether the delimiter is inserted after the component."),
+		},
+	}
+}
+
+// ValidateConfig checks that the length bounds are consistent.
+func (r *ComponentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ComponentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value types.Int64
+	}{{"min_length", config.MinLength}, {"max_length", config.MaxLength}, {"sort_order", config.SortOrder}} {
+		if knownInt64(attribute.value) && attribute.value.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Invalid component setting",
+				fmt.Sprintf("%s must not be negative, got %d.", attribute.name, attribute.value.ValueInt64()))
+		}
+	}
+	if knownInt64(config.MinLength) && knownInt64(config.MaxLength) && config.MinLength.ValueInt64() > config.MaxLength.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("min_length"), "Invalid component setting",
+			fmt.Sprintf("min_length (%d) must not be greater than max_length (%d).", config.MinLength.ValueInt64(), config.MaxLength.ValueInt64()))
+	}
+}
+
+// ModifyPlan warns when the planned sort order is used by another component in the Naming
+// Tool. Terraform plans each resource on its own, so the plans of the other components are
+// unknown here: the other component may be planned to move in the same run, so the plan does
+// not fail, and the sort order is checked again when the component is updated.
+func (r *ComponentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan ComponentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !knownInt64(plan.SortOrder) || plan.Name.IsUnknown() {
+		return
+	}
+
+	components, err := apiclient.NewResourceComponentService(r.client).GetAllResourceComponents(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the components to check the sort order.", err)
+		return
+	}
+
+	sortOrder := plan.SortOrder.ValueInt64()
+	for _, component := range *components {
+		if strings.EqualFold(component.Name, plan.Name.ValueString()) {
+			if int64(component.SortOrder) == sortOrder {
+				return
+			}
+			continue
+		}
+		if int64(component.SortOrder) == sortOrder {
+			resp.Diagnostics.AddAttributeWarning(path.Root("sort_order"), "Component sort order in use",
+				fmt.Sprintf("The sort order %d is used by the component %q in the Naming Tool. Sort orders must be unique across all components, so the apply fails unless %q moves to another sort order first.", sortOrder, component.Name, component.Name))
+			return
+		}
+	}
+}
+
+// Configure prepares the struct.
+func (r *ComponentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *apiclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Create takes over the settings of an existing component, since components cannot be created.
+func (r *ComponentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_component", "Create")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan ComponentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client not configured", "The provider client has not been configured.")
+		return
+	}
+
+	current, err := apiclient.NewResourceComponentService(r.client).FindResourceComponentByName(ctx, plan.Name.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Could not find the component %q", plan.Name.ValueString()), err)
+		return
+	}
+
+	component, ok := r.apply(ctx, plan, *current, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, component)...)
+}
+
+// Read handles reading the resource data.
+func (r *ComponentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_component", "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state ComponentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	component, err := apiclient.NewResourceComponentService(r.client).GetResourceComponent(ctx, strconv.FormatInt(state.ID.ValueInt64(), 10))
+	if (err != nil && errors.Is(err, utils.ErrNotFound)) || (err == nil && component.Id == 0) {
+		tflog.Warn(ctx, fmt.Sprintf("The component %d no longer exists, removing it from the state", state.ID.ValueInt64()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the component.", err)
+		return
+	}
+
+	model := newComponentResourceModel(*component)
+	if strings.EqualFold(component.Name, state.Name.ValueString()) {
+		model.Name = state.Name
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// Update handles updating the resource.
+func (r *ComponentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_component", "Update")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan, state ComponentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := apiclient.NewResourceComponentService(r.client).GetResourceComponent(ctx, strconv.FormatInt(state.ID.ValueInt64(), 10))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the component.", err)
+		return
+	}
+
+	component, ok := r.apply(ctx, plan, *current, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, component)...)
+}
+
+// Delete removes the component from the state. Components cannot be deleted, so the Naming
+// Tool keeps the applied settings.
+func (r *ComponentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_component", "Delete")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state ComponentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Component %s is no longer managed, its settings are left in the Naming Tool", state.Name.ValueString()))
+}
+
+// ImportState imports the component by its ID or its name.
+func (r *ComponentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_component", "ImportState")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	svc := apiclient.NewResourceComponentService(r.client)
+	var component *models.ResourceComponent
+	var err error
+	if _, parseErr := strconv.ParseInt(req.ID, 10, 64); parseErr == nil {
+		component, err = svc.GetResourceComponent(ctx, req.ID)
+		if err == nil && component.Id == 0 {
+			err = fmt.Errorf("%w: component %s", utils.ErrNotFound, req.ID)
+		}
+	} else {
+		component, err = svc.FindResourceComponentByName(ctx, req.ID)
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Could not find the component %q", req.ID), err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newComponentResourceModel(*component))...)
+}
+
+// apply sends the planned settings over the current settings of the component, and returns
+// the model of the sent component.
+func (r *ComponentResource) apply(ctx context.Context, plan ComponentResourceModel, current models.ResourceComponent, diags *diag.Diagnostics) (ComponentResourceModel, bool) {
+	component := plan.applyTo(current)
+	if err := apiclient.NewResourceComponentService(r.client).UpdateResourceComponent(ctx, component); err != nil {
+		addAPIError(diags, fmt.Sprintf("Failed to update the component %q.", component.Name), err)
+		return ComponentResourceModel{}, false
+	}
+	tflog.Info(ctx, fmt.Sprintf("Updated component %s", component.Name), map[string]interface{}{"id": component.Id})
+
+	model := newComponentResourceModel(component)
+	model.Name = plan.Name
+	return model, true
+}
+
+// applyTo returns component with the settings of the model that are known.
+func (m ComponentResourceModel) applyTo(component models.ResourceComponent) models.ResourceComponent {
+	if knownString(m.DisplayName) {
+		component.DisplayName = m.DisplayName.ValueString()
+	}
+	if knownInt64(m.SortOrder) {
+		component.SortOrder = int(m.SortOrder.ValueInt64())
+	}
+	if knownInt64(m.MinLength) {
+		component.MinLength = models.StringInt(m.MinLength.ValueInt64())
+	}
+	if knownInt64(m.MaxLength) {
+		component.MaxLength = models.StringInt(m.MaxLength.ValueInt64())
+	}
+	for _, setting := range []struct {
+		value  types.Bool
+		target *models.Bool
+	}{
+		{m.Enabled, &component.Enabled},
+		{m.EnforceRandom, &component.EnforceRandom},
+		{m.Alphanumeric, &component.Alphanumeric},
+		{m.ApplyDelimiterBefore, &component.ApplyDelimiterBefore},
+		{m.ApplyDelimiterAfter, &component.ApplyDelimiterAfter},
+	} {
+		if !setting.value.IsUnknown() && !setting.value.IsNull() {
+			*setting.target = models.Bool(setting.value.ValueBool())
+		}
+	}
+	return component
+}
+
+// newComponentResourceModel returns the model of a component returned by the Naming Tool.
+func newComponentResourceModel(component models.ResourceComponent) ComponentResourceModel {
+	return ComponentResourceModel{
+		ID:                   types.Int64Value(component.Id),
+		Name:                 types.StringValue(component.Name),
+		DisplayName:          types.StringValue(component.DisplayName),
+		Enabled:              types.BoolValue(bool(component.Enabled)),
+		SortOrder:            types.Int64Value(int64(component.SortOrder)),
+		MinLength:            types.Int64Value(int64(component.MinLength)),
+		MaxLength:            types.Int64Value(int64(component.MaxLength)),
+		EnforceRandom:        types.BoolValue(bool(component.EnforceRandom)),
+		Alphanumeric:         types.BoolValue(bool(component.Alphanumeric)),
+		ApplyDelimiterBefore: types.BoolValue(bool(component.ApplyDelimiterBefore)),
+		ApplyDelimiterAfter:  types.BoolValue(bool(component.ApplyDelimiterAfter)),
+	}
+}
+
+// knownInt64 reports whether value is neither null nor unknown.
+func knownInt64(value types.Int64) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// knownString reports whether value is neither null nor unknown.
+func knownString(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newComponentResource returns the configured aznamingtool_component resource, its empty state
+// and the Naming Tool behind it, which holds three components.
+func newComponentResource(t *testing.T) (*ComponentResource, tfsdk.State, *namingtooltest.Server) {
+	server, client := newTestNamingTool(t)
+	server.Put(namingtooltest.Components,
+		models.ResourceComponent{Id: 1, Name: "ResourceType", DisplayName: "Resource Type", Enabled: true, SortOrder: 1, MinLength: 1, MaxLength: 10},
+		models.ResourceComponent{Id: 2, Name: "ResourceEnvironment", DisplayName: "Environment", Enabled: true, SortOrder: 2, MinLength: 1, MaxLength: 5},
+		models.ResourceComponent{Id: 3, Name: "ResourceLocation", DisplayName: "Location", Enabled: true, SortOrder: 3, MinLength: 1, MaxLength: 5},
+	)
+	r, empty := newTestResource(t, &ComponentResource{}, client)
+	return r, empty, server
+}
+
+// environmentComponent returns the ResourceEnvironment component held by server.
+func environmentComponent(server *namingtooltest.Server) models.ResourceComponent {
+	component, _ := namingtooltest.Record[models.ResourceComponent](server, namingtooltest.Components, 2)
+	return component
+}
+
+// plannedComponent returns a plan for the component that only sets the sort order and max length.
+func plannedComponent(t *testing.T, empty tfsdk.State, name string, sortOrder int64, maxLength int64) tfsdk.Plan {
+	return newTestPlan(t, empty, ComponentResourceModel{
+		ID:                   types.Int64Unknown(),
+		Name:                 types.StringValue(name),
+		DisplayName:          types.StringUnknown(),
+		Enabled:              types.BoolUnknown(),
+		SortOrder:            types.Int64Value(sortOrder),
+		MinLength:            types.Int64Unknown(),
+		MaxLength:            types.Int64Value(maxLength),
+		EnforceRandom:        types.BoolUnknown(),
+		Alphanumeric:         types.BoolUnknown(),
+		ApplyDelimiterBefore: types.BoolUnknown(),
+		ApplyDelimiterAfter:  types.BoolUnknown(),
+	})
+}
+
+func TestComponentResourceCreateKeepsUnsetSettings(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newComponentResource(t)
+
+	resp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plannedComponent(t, empty, "resourceenvironment", 5, 3)}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	assert.Equal(t, models.ResourceComponent{Id: 2, Name: "ResourceEnvironment", DisplayName: "Environment", Enabled: true, SortOrder: 5, MinLength: 1, MaxLength: 3}, environmentComponent(server))
+	var state ComponentResourceModel
+	resp.State.Get(ctx, &state)
+	assert.Equal(t, "resourceenvironment", state.Name.ValueString())
+	assert.Equal(t, "Environment", state.DisplayName.ValueString())
+	assert.Equal(t, int64(2), state.ID.ValueInt64())
+}
+
+func TestComponentResourceModifyPlanWarnsAboutSortOrder(t *testing.T) {
+	ctx := context.Background()
+	r, empty, _ := newComponentResource(t)
+
+	modifyPlan := func(name string, sortOrder int64) *resource.ModifyPlanResponse {
+		plan := plannedComponent(t, empty, name, sortOrder, 5)
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: empty}, resp)
+		return resp
+	}
+
+	// The location may move away in the same run, so the taken sort order only warns
+	resp := modifyPlan("ResourceEnvironment", 3)
+	assert.False(t, resp.Diagnostics.HasError())
+	if assert.Len(t, resp.Diagnostics.Warnings(), 1) {
+		assert.Equal(t, "Component sort order in use", resp.Diagnostics.Warnings()[0].Summary())
+	}
+
+	assert.Empty(t, modifyPlan("ResourceEnvironment", 2).Diagnostics)
+	assert.Empty(t, modifyPlan("ResourceEnvironment", 4).Diagnostics)
+}
+
+func TestComponentResourceUpdateChecksSortOrder(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newComponentResource(t)
+
+	// The sort order of the location is taken when the environment is applied
+	resp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plannedComponent(t, empty, "ResourceEnvironment", 3, 5)}, &resp)
+	if assert.True(t, resp.Diagnostics.HasError()) {
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), `the sort order 3 is already used by the component "ResourceLocation"`)
+	}
+	assert.Equal(t, 2, environmentComponent(server).SortOrder)
+
+	// It is free once the location moved
+	resp = resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plannedComponent(t, empty, "ResourceLocation", 4, 5)}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	resp = resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plannedComponent(t, empty, "ResourceEnvironment", 3, 5)}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, 3, environmentComponent(server).SortOrder)
+}
@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CustomComponentResource{}
+	_ resource.ResourceWithConfigure   = &CustomComponentResource{}
+	_ resource.ResourceWithImportState = &CustomComponentResource{}
+)
+
+// NewCustomComponentResource creates the aznamingtool_custom_component resource.
+func NewCustomComponentResource() resource.Resource {
+	return &CustomComponentResource{}
+}
+
+// CustomComponentResource manages a custom component of the Naming Tool and its values.
+type CustomComponentResource struct {
+	client *apiclient.APIClient
+}
+
+// CustomComponentResourceModel describes the resource data model.
+type CustomComponentResourceModel struct {
+	ID          types.Int64                 `tfsdk:"id"`
+	Name        types.String                `tfsdk:"name"`
+	DisplayName types.String                `tfsdk:"display_name"`
+	SortOrder   types.Int64                 `tfsdk:"sort_order"`
+	Values      []CustomComponentValueModel `tfsdk:"values"`
+}
+
+// CustomComponentValueModel describes one allowed value of the custom component.
+type CustomComponentValueModel struct {
+	Name      types.String `tfsdk:"name"`
+	ShortName types.String `tfsdk:"short_name"`
+	SortOrder types.Int64  `tfsdk:"sort_order"`
+	MinLength types.Int64  `tfsdk:"min_length"`
+	MaxLength types.Int64  `tfsdk:"max_length"`
+}
+
+// Metadata returns the resource type name.
+func (r *CustomComponentResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "aznamingtool_custom_component"
+}
+
+// Schema defines the schema for the resource.
+func (r *CustomComponentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom component of the Naming Tool and its allowed values.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:      true,
+				Description:   "The ID of the custom component in the Naming Tool.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "The name of the custom component, such as cost_center.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"display_name": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "The name of the custom component shown in the Naming Tool. Defaults to name.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"sort_order": schema.Int64Attribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "The position of the custom component in generated names. It must be unique across all components. Defaults to the last position.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"values": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "The allowed values of the custom component.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the value, which identifies it in the Naming Tool.",
+						},
+						"short_name": schema.StringAttribute{
+							Required:    true,
+							Description: "The short name of the value, used in generated names.",
+						},
+						"sort_order": schema.Int64Attribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(0),
+							Description: "The position of the value in the lists of the Naming Tool.",
+						},
+						"min_length": schema.Int64Attribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(1),
+							Description: "The minimum length of the value.",
+						},
+						"max_length": schema.Int64Attribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(10),
+							Description: "The maximum length of the value.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the struct.
+func (r *CustomComponentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *apiclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Create handles the creation of the resource. A disabled custom component with the same name,
+// as left behind by a destroy, is enabled again instead of adding another one.
+func (r *CustomComponentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_custom_component", "Create")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan CustomComponentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client not configured", "The provider client has not been configured.")
+		return
+	}
+
+	svc := apiclient.NewResourceComponentService(r.client)
+	components, err := svc.GetAllResourceComponents(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the components.", err)
+		return
+	}
+
+	name := plan.Name.ValueString()
+	component := models.ResourceComponent{
+		Name:                 name,
+		IsCustom:             true,
+		MinLength:            1,
+		MaxLength:            10,
+		ApplyDelimiterBefore: true,
+		ApplyDelimiterAfter:  true,
+	}
+	for _, existing := range *components {
+		if !strings.EqualFold(existing.Name, name) {
+			continue
+		}
+		if !existing.IsCustom {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Built-in component",
+				fmt.Sprintf("%q is a built-in component of the Naming Tool. Use aznamingtool_component to manage its settings.", existing.Name))
+			return
+		}
+		if existing.Enabled {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Custom component already exists",
+				fmt.Sprintf("The custom component %q already exists in the Naming Tool. Import it with terraform import to manage it.", existing.Name))
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Enabling the disabled custom component %s", existing.Name), map[string]interface{}{"id": existing.Id})
+		component = existing
+	}
+	component.Enabled = true
+	component.DisplayName = name
+	if knownString(plan.DisplayName) {
+		component.DisplayName = plan.DisplayName.ValueString()
+	}
+	if knownInt64(plan.SortOrder) {
+		component.SortOrder = int(plan.SortOrder.ValueInt64())
+	}
+
+	if component.Id != 0 {
+		err = svc.UpdateResourceComponent(ctx, component)
+	} else {
+		var created *models.ResourceComponent
+		if created, err = svc.CreateResourceComponent(ctx, component); err == nil {
+			component = *created
+		}
+	}
+	if err != nil {
+		addSortOrderError(&resp.Diagnostics, fmt.Sprintf("Failed to create the custom component %q.", name), err)
+		return
+	}
+
+	plan.ID = types.Int64Value(component.Id)
+	plan.DisplayName = types.StringValue(component.DisplayName)
+	plan.SortOrder = types.Int64Value(int64(component.SortOrder))
+	// The component exists even if its values fail, so it is kept in the state. Terraform
+	// taints it, and the next apply replaces it, which sends the values again.
+	r.applyValues(ctx, component.Name, plan.Values, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource data.
+func (r *CustomComponentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_custom_component", "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state CustomComponentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	component, err := r.getComponent(ctx, state.ID.ValueInt64())
+	if errors.Is(err, utils.ErrNotFound) {
+		// The component was deleted or disabled in the Naming Tool, so Terraform has to create it again.
+		tflog.Warn(ctx, fmt.Sprintf("The custom component %d no longer exists, removing it from the state", state.ID.ValueInt64()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the custom component.", err)
+		return
+	}
+
+	values, err := apiclient.NewCustomComponentService(r.client).GetCustomComponentByParentType(ctx, component.Name)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the values of the custom component.", err)
+		return
+	}
+
+	if !strings.EqualFold(component.Name, state.Name.ValueString()) {
+		state.Name = types.StringValue(component.Name)
+	}
+	state.DisplayName = types.StringValue(component.DisplayName)
+	state.SortOrder = types.Int64Value(int64(component.SortOrder))
+	state.Values = make([]CustomComponentValueModel, 0, len(*values))
+	for _, value := range *values {
+		state.Values = append(state.Values, CustomComponentValueModel{
+			Name:      types.StringValue(value.Name),
+			ShortName: types.StringValue(value.ShortName),
+			SortOrder: types.Int64Value(int64(value.SortOrder)),
+			MinLength: types.Int64Value(int64(value.MinLength)),
+			MaxLength: types.Int64Value(int64(value.MaxLength)),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update handles updating the resource.
+func (r *CustomComponentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_custom_component", "Update")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan, state CustomComponentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	component, err := r.getComponent(ctx, state.ID.ValueInt64())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the custom component.", err)
+		return
+	}
+
+	updated := *component
+	if knownString(plan.DisplayName) {
+		updated.DisplayName = plan.DisplayName.ValueString()
+	}
+	if knownInt64(plan.SortOrder) {
+		updated.SortOrder = int(plan.SortOrder.ValueInt64())
+	}
+	if updated != *component {
+		if err := apiclient.NewResourceComponentService(r.client).UpdateResourceComponent(ctx, updated); err != nil {
+			addSortOrderError(&resp.Diagnostics, fmt.Sprintf("Failed to update the custom component %q.", component.Name), err)
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	plan.DisplayName = types.StringValue(updated.DisplayName)
+	plan.SortOrder = types.Int64Value(int64(updated.SortOrder))
+	if !r.applyValues(ctx, component.Name, plan.Values, &resp.Diagnostics) {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles deleting the resource. The values are deleted with the custom component, but
+// the Naming Tool cannot delete components, so the custom component itself is disabled.
+func (r *CustomComponentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_custom_component", "Delete")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state CustomComponentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := strconv.FormatInt(state.ID.ValueInt64(), 10)
+	err := apiclient.NewCustomComponentService(r.client).DeleteCustomComponentByParentId(ctx, id)
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Failed to delete the values of the custom component.", err)
+		return
+	}
+
+	component, err := r.getComponent(ctx, state.ID.ValueInt64())
+	if errors.Is(err, utils.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the custom component.", err)
+		return
+	}
+	component.Enabled = false
+	if err := apiclient.NewResourceComponentService(r.client).UpdateResourceComponent(ctx, *component); err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Failed to disable the custom component %q.", component.Name), err)
+	}
+}
+
+// ImportState imports the custom component by its ID or its name.
+func (r *CustomComponentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_custom_component", "ImportState")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var component *models.ResourceComponent
+	var err error
+	if id, parseErr := strconv.ParseInt(req.ID, 10, 64); parseErr == nil {
+		component, err = r.getComponent(ctx, id)
+	} else {
+		component, err = apiclient.NewResourceComponentService(r.client).FindResourceComponentByName(ctx, req.ID)
+		if err == nil && !component.IsCustom {
+			err = fmt.Errorf("%w: %q is a built-in component, use aznamingtool_component to manage it", utils.ErrNotFound, component.Name)
+		}
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Could not find the custom component %q", req.ID), err)
+		return
+	}
+
+	// The other attributes and the values are set by the Read that follows the import
+	resp.Diagnostics.Append(resp.State.Set(ctx, CustomComponentResourceModel{
+		ID:          types.Int64Value(component.Id),
+		Name:        types.StringValue(component.Name),
+		DisplayName: types.StringValue(component.DisplayName),
+		SortOrder:   types.Int64Value(int64(component.SortOrder)),
+	})...)
+}
+
+// getComponent returns the enabled custom component with the ID, or an error matching
+// utils.ErrNotFound if there is none.
+func (r *CustomComponentResource) getComponent(ctx context.Context, id int64) (*models.ResourceComponent, error) {
+	component, err := apiclient.NewResourceComponentService(r.client).GetResourceComponent(ctx, strconv.FormatInt(id, 10))
+	if err != nil {
+		return nil, err
+	}
+	if component.Id == 0 || !component.IsCustom || !component.Enabled {
+		return nil, fmt.Errorf("%w: custom component %d", utils.ErrNotFound, id)
+	}
+	return component, nil
+}
+
+// applyValues makes the values of the custom component match values, and reports whether it succeeded.
+func (r *CustomComponentResource) applyValues(ctx context.Context, parentComponent string, values []CustomComponentValueModel, diags *diag.Diagnostics) bool {
+	desired := make([]models.CustomComponent, 0, len(values))
+	for _, value := range values {
+		desired = append(desired, models.CustomComponent{
+			Name:      value.Name.ValueString(),
+			ShortName: value.ShortName.ValueString(),
+			SortOrder: int(value.SortOrder.ValueInt64()),
+			MinLength: models.StringInt(value.MinLength.ValueInt64()),
+			MaxLength: models.StringInt(value.MaxLength.ValueInt64()),
+		})
+	}
+
+	if _, err := apiclient.NewCustomComponentService(r.client).ApplyCustomComponents(ctx, parentComponent, desired); err != nil {
+		addAPIError(diags, fmt.Sprintf("Failed to update the values of the custom component %q.", parentComponent), err)
+		return false
+	}
+	return true
+}
+
+// addSortOrderError adds an error on sort_order when err reports a sort order that another
+// component already uses, and an API error otherwise.
+func addSortOrderError(diags *diag.Diagnostics, summary string, err error) {
+	if errors.Is(err, utils.ErrConflict) {
+		diags.AddAttributeError(path.Root("sort_order"), "Duplicate component sort order",
+			fmt.Sprintf("%s. Choose a sort order that no other component uses, or leave it unset.", err))
+		return
+	}
+	addAPIError(diags, summary, err)
+}
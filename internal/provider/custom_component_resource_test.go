@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCustomComponentResource returns the configured aznamingtool_custom_component resource,
+// its empty state and the Naming Tool behind it, which holds two built-in components.
+func newCustomComponentResource(t *testing.T) (*CustomComponentResource, tfsdk.State, *namingtooltest.Server) {
+	server, client := newTestNamingTool(t)
+	server.Put(namingtooltest.Components,
+		models.ResourceComponent{Id: 1, Name: "ResourceType", DisplayName: "Resource Type", Enabled: true, SortOrder: 1},
+		models.ResourceComponent{Id: 2, Name: "ResourceEnvironment", DisplayName: "Environment", Enabled: true, SortOrder: 4},
+	)
+	r, empty := newTestResource(t, &CustomComponentResource{}, client)
+	return r, empty, server
+}
+
+// plannedCustomComponent returns a plan for the cost_center custom component with the Finance value.
+func plannedCustomComponent(t *testing.T, empty tfsdk.State) (tfsdk.Plan, []CustomComponentValueModel) {
+	values := []CustomComponentValueModel{{
+		Name:      types.StringValue("Finance"),
+		ShortName: types.StringValue("fin"),
+		SortOrder: types.Int64Value(1),
+		MinLength: types.Int64Value(1),
+		MaxLength: types.Int64Value(5),
+	}}
+	return newTestPlan(t, empty, CustomComponentResourceModel{
+		ID:          types.Int64Unknown(),
+		Name:        types.StringValue("cost_center"),
+		DisplayName: types.StringUnknown(),
+		SortOrder:   types.Int64Unknown(),
+		Values:      values,
+	}), values
+}
+
+func TestCustomComponentResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newCustomComponentResource(t)
+	plan, _ := plannedCustomComponent(t, empty)
+	createResp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	// The custom component is added after the other components, with its value
+	components := namingtooltest.Records[models.ResourceComponent](server, namingtooltest.Components)
+	if assert.Len(t, components, 3) {
+		assert.Equal(t, models.ResourceComponent{Id: 3, Name: "cost_center", DisplayName: "cost_center", Enabled: true, SortOrder: 5, IsCustom: true, MinLength: 1, MaxLength: 10, ApplyDelimiterBefore: true, ApplyDelimiterAfter: true}, components[2])
+	}
+	assert.Equal(t, []models.CustomComponent{{Id: 1, ParentComponent: "cost_center", Name: "Finance", ShortName: "fin", SortOrder: 1, MinLength: 1, MaxLength: 5}},
+		namingtooltest.Records[models.CustomComponent](server, namingtooltest.CustomComponents))
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var state CustomComponentResourceModel
+	readResp.State.Get(ctx, &state)
+	assert.Equal(t, int64(3), state.ID.ValueInt64())
+	assert.Len(t, state.Values, 1)
+
+	// Destroying deletes the values and disables the component, which is then gone on refresh
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	assert.Empty(t, namingtooltest.Records[models.CustomComponent](server, namingtooltest.CustomComponents))
+	component, _ := namingtooltest.Record[models.ResourceComponent](server, namingtooltest.Components, 3)
+	assert.False(t, bool(component.Enabled))
+
+	readResp = resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	// Creating it again enables the disabled component
+	createResp = resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	assert.Len(t, namingtooltest.Records[models.ResourceComponent](server, namingtooltest.Components), 3)
+	component, _ = namingtooltest.Record[models.ResourceComponent](server, namingtooltest.Components, 3)
+	assert.True(t, bool(component.Enabled))
+}
+
+func TestCustomComponentResourceCreateKeepsComponentWhenValuesFail(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newCustomComponentResource(t)
+	server.RejectWrites(namingtooltest.CustomComponents, http.StatusBadRequest)
+	plan, values := plannedCustomComponent(t, empty)
+	createResp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	// The error taints the created component, which is saved with the planned values
+	assert.True(t, createResp.Diagnostics.HasError())
+	var state CustomComponentResourceModel
+	createResp.State.Get(ctx, &state)
+	assert.Equal(t, int64(3), state.ID.ValueInt64())
+	assert.Equal(t, values, state.Values)
+}
+
+func TestCustomComponentResourceCreateRejectsUsedSortOrder(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newCustomComponentResource(t)
+	plan := newTestPlan(t, empty, CustomComponentResourceModel{
+		ID:          types.Int64Unknown(),
+		Name:        types.StringValue("cost_center"),
+		DisplayName: types.StringUnknown(),
+		SortOrder:   types.Int64Value(4),
+	})
+	createResp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	// The environment keeps its sort order and no component is added
+	if assert.True(t, createResp.Diagnostics.HasError()) {
+		assert.Equal(t, "Duplicate component sort order", createResp.Diagnostics.Errors()[0].Summary())
+	}
+	assert.Len(t, namingtooltest.Records[models.ResourceComponent](server, namingtooltest.Components), 2)
+	assert.Zero(t, server.Writes(namingtooltest.Components))
+}
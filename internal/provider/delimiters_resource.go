@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+)
+
+// delimitersID is the ID of the aznamingtool_delimiters resource, since the Naming Tool has a
+// single delimiter list.
+const delimitersID = "delimiters"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DelimitersResource{}
+	_ resource.ResourceWithConfigure      = &DelimitersResource{}
+	_ resource.ResourceWithImportState    = &DelimitersResource{}
+	_ resource.ResourceWithValidateConfig = &DelimitersResource{}
+)
+
+// NewDelimitersResource creates the aznamingtool_delimiters resource.
+func NewDelimitersResource() resource.Resource {
+	return &DelimitersResource{}
+}
+
+// DelimitersResource manages the complete delimiter list of the Naming Tool.
+type DelimitersResource struct {
+	client *apiclient.APIClient
+}
+
+// DelimitersResourceModel describes the resource data model.
+type DelimitersResourceModel struct {
+	ID         types.String     `tfsdk:"id"`
+	Delimiters []DelimiterModel `tfsdk:"delimiters"`
+}
+
+// DelimiterModel describes one delimiter of the list.
+type DelimiterModel struct {
+	Name      types.String `tfsdk:"name"`
+	Character types.String `tfsdk:"character"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	SortOrder types.Int64  `tfsdk:"sort_order"`
+}
+
+// Metadata returns the resource type name.
+func (r *DelimitersResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "aznamingtool_delimiters"
+}
+
+// Schema defines the schema for the resource.
+func (r *DelimitersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete delimiter list of the Naming Tool. Destroying it restores the default delimiters.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"delimiters": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The delimiters of the Naming Tool. Exactly one of them must be enabled.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the delimiter, which identifies it in the Naming Tool.",
+						},
+						"character": schema.StringAttribute{
+							Required:    true,
+							Description: "The character inserted between the components of generated names. Empty for no delimiter.",
+						},
+						"enabled": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether generated names use the delimiter.",
+						},
+						"sort_order": schema.Int64Attribute{
+							Required:    true,
+							Description: "The position of the delimiter in the lists of the Naming Tool.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the delimiter names are unique and exactly one delimiter is enabled.
+func (r *DelimitersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var list types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("delimiters"), &list)...)
+	if resp.Diagnostics.HasError() || list.IsNull() || list.IsUnknown() {
+		return
+	}
+	var delimiters []DelimiterModel
+	resp.Diagnostics.Append(list.ElementsAs(ctx, &delimiters, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := map[string]bool{}
+	enabled, unknown := 0, false
+	for i, delimiter := range delimiters {
+		if !delimiter.Name.IsUnknown() && !delimiter.Name.IsNull() {
+			key := strings.ToLower(delimiter.Name.ValueString())
+			if names[key] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("delimiters").AtListIndex(i).AtName("name"),
+					"Duplicate delimiter",
+					fmt.Sprintf("The delimiter %q is listed more than once. Delimiter names are compared ignoring case.", delimiter.Name.ValueString()),
+				)
+			}
+			names[key] = true
+		}
+		if delimiter.Enabled.IsUnknown() {
+			unknown = true
+		} else if delimiter.Enabled.ValueBool() {
+			enabled++
+		}
+	}
+	if !unknown && enabled != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("delimiters"),
+			"Invalid delimiter list",
+			fmt.Sprintf("Exactly one delimiter must be enabled, but %d are. Enable a delimiter with an empty character for names without delimiter.", enabled),
+		)
+	}
+}
+
+// Configure prepares the struct.
+func (r *DelimitersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *apiclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Create handles the creation of the resource by applying the configured delimiters.
+func (r *DelimitersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_delimiters", "Create")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan DelimitersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client not configured", "The provider client has not been configured.")
+		return
+	}
+
+	if !r.apply(ctx, plan.Delimiters, &resp.Diagnostics) {
+		return
+	}
+	plan.ID = types.StringValue(delimitersID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read handles reading the resource data.
+func (r *DelimitersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_delimiters", "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state DelimitersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delimiters, err := apiclient.NewResourceDelimiterService(r.client).GetAllResourceDelimiters(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the delimiters.", err)
+		return
+	}
+
+	// Keep the order of the state, so that only changed delimiters show up in the plan
+	byName := make(map[string]models.ResourceDelimiter, len(*delimiters))
+	for _, delimiter := range *delimiters {
+		byName[strings.ToLower(delimiter.Name)] = delimiter
+	}
+	var refreshed []DelimiterModel
+	for _, delimiter := range state.Delimiters {
+		key := strings.ToLower(delimiter.Name.ValueString())
+		if current, ok := byName[key]; ok {
+			refreshed = append(refreshed, newDelimiterModel(current))
+			delete(byName, key)
+		}
+	}
+	// Delimiters added outside of Terraform are only relevant when they are enabled, since
+	// disabled delimiters cannot be deleted
+	for _, delimiter := range sortDelimiters(*delimiters) {
+		if _, ok := byName[strings.ToLower(delimiter.Name)]; ok && bool(delimiter.Enabled) {
+			refreshed = append(refreshed, newDelimiterModel(delimiter))
+		}
+	}
+
+	state.Delimiters = refreshed
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update handles updating the resource by applying the configured delimiters.
+func (r *DelimitersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_delimiters", "Update")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan DelimitersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.apply(ctx, plan.Delimiters, &resp.Diagnostics) {
+		return
+	}
+	plan.ID = types.StringValue(delimitersID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles deleting the resource. The Naming Tool cannot delete delimiters, so the
+// default delimiters are restored instead.
+func (r *DelimitersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_delimiters", "Delete")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	_, err := apiclient.NewResourceDelimiterService(r.client).ApplyResourceDelimiters(ctx, apiclient.DefaultResourceDelimiters())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to restore the default delimiters.", err)
+		return
+	}
+	tflog.Info(ctx, "Restored the default delimiters")
+}
+
+// ImportState imports all the delimiters of the Naming Tool. The import ID is ignored.
+func (r *DelimitersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_delimiters", "ImportState")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	delimiters, err := apiclient.NewResourceDelimiterService(r.client).GetAllResourceDelimiters(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the delimiters.", err)
+		return
+	}
+
+	state := DelimitersResourceModel{ID: types.StringValue(delimitersID)}
+	for _, delimiter := range sortDelimiters(*delimiters) {
+		state.Delimiters = append(state.Delimiters, newDelimiterModel(delimiter))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// apply sends the delimiters that differ from the Naming Tool, and reports whether it succeeded.
+func (r *DelimitersResource) apply(ctx context.Context, delimiters []DelimiterModel, diags *diag.Diagnostics) bool {
+	desired := make([]models.ResourceDelimiter, 0, len(delimiters))
+	for _, delimiter := range delimiters {
+		desired = append(desired, models.ResourceDelimiter{
+			Name:      delimiter.Name.ValueString(),
+			Delimiter: delimiter.Character.ValueString(),
+			Enabled:   models.Bool(delimiter.Enabled.ValueBool()),
+			SortOrder: int(delimiter.SortOrder.ValueInt64()),
+		})
+	}
+
+	if _, err := apiclient.NewResourceDelimiterService(r.client).ApplyResourceDelimiters(ctx, desired); err != nil {
+		addAPIError(diags, "Failed to update the delimiters.", err)
+		return false
+	}
+	return true
+}
+
+// sortDelimiters returns the delimiters ordered by their sort order.
+func sortDelimiters(delimiters []models.ResourceDelimiter) []models.ResourceDelimiter {
+	sorted := append([]models.ResourceDelimiter(nil), delimiters...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].SortOrder != sorted[j].SortOrder {
+			return sorted[i].SortOrder < sorted[j].SortOrder
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+	return sorted
+}
+
+// newDelimiterModel returns the model of a delimiter returned by the Naming Tool.
+func newDelimiterModel(delimiter models.ResourceDelimiter) DelimiterModel {
+	return DelimiterModel{
+		Name:      types.StringValue(delimiter.Name),
+		Character: types.StringValue(delimiter.Delimiter),
+		Enabled:   types.BoolValue(bool(delimiter.Enabled)),
+		SortOrder: types.Int64Value(int64(delimiter.SortOrder)),
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func delimiter(name string, character string, enabled bool) DelimiterModel {
+	return DelimiterModel{
+		Name:      types.StringValue(name),
+		Character: types.StringValue(character),
+		Enabled:   types.BoolValue(enabled),
+		SortOrder: types.Int64Value(1),
+	}
+}
+
+func TestDelimitersResourceValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		delimiters []DelimiterModel
+		err        string
+	}{
+		{name: "one enabled", delimiters: []DelimiterModel{delimiter("dash", "-", true), delimiter("none", "", false)}},
+		{name: "none enabled", delimiters: []DelimiterModel{delimiter("dash", "-", false)}, err: "Invalid delimiter list"},
+		{name: "two enabled", delimiters: []DelimiterModel{delimiter("dash", "-", true), delimiter("period", ".", true)}, err: "Invalid delimiter list"},
+		{name: "duplicate name", delimiters: []DelimiterModel{delimiter("dash", "-", true), delimiter("Dash", "_", false)}, err: "Duplicate delimiter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, state := newTestResource(t, NewDelimitersResource().(resource.ResourceWithValidateConfig), nil)
+			state.Set(ctx, DelimitersResourceModel{ID: types.StringNull(), Delimiters: tt.delimiters})
+
+			var resp resource.ValidateConfigResponse
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+			if tt.err == "" {
+				assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			} else if assert.True(t, resp.Diagnostics.HasError()) {
+				assert.Equal(t, tt.err, resp.Diagnostics.Errors()[0].Summary())
+			}
+		})
+	}
+}
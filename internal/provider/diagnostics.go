@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+)
+
+// addAPIError adds an error diagnostic for a failed API call. When err is an
+// *apiclient.APIError the detail shows the Naming Tool's own message instead of
+// the raw error chain.
+func addAPIError(diags *diag.Diagnostics, summary string, err error) {
+	var apiErr *apiclient.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	detail := fmt.Sprintf("The Naming Tool answered %s %s with %d %s.", apiErr.Method, apiErr.URL, apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	if apiErr.Message != "" {
+		detail += fmt.Sprintf("\n\nServer message: %s", apiErr.Message)
+	}
+	if apiErr.RequestID != "" {
+		detail += fmt.Sprintf("\n\nRequest ID: %s", apiErr.RequestID)
+	}
+	diags.AddError(summary, detail)
+}
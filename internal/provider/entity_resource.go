@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &EntityResource[models.ResourceEnvironment]{}
+	_ resource.ResourceWithConfigure   = &EntityResource[models.ResourceEnvironment]{}
+	_ resource.ResourceWithImportState = &EntityResource[models.ResourceEnvironment]{}
+)
+
+// NewEnvironmentResource creates the aznamingtool_environment resource.
+func NewEnvironmentResource() resource.Resource {
+	return newEntityResource("aznamingtool_environment", "environment", apiclient.NewResourceEnvironmentService,
+		func(base models.ResourceBaseEntity) models.ResourceEnvironment {
+			return models.ResourceEnvironment{ResourceBaseEntity: base}
+		})
+}
+
+// NewFunctionResource creates the aznamingtool_function resource.
+func NewFunctionResource() resource.Resource {
+	return newEntityResource("aznamingtool_function", "function", apiclient.NewResourceFunctionService,
+		func(base models.ResourceBaseEntity) models.ResourceFunction {
+			return models.ResourceFunction{ResourceBaseEntity: base}
+		})
+}
+
+// NewLocationResource creates the aznamingtool_location resource.
+func NewLocationResource() resource.Resource {
+	return newEntityResource("aznamingtool_location", "location", apiclient.NewResourceLocationService,
+		func(base models.ResourceBaseEntity) models.ResourceLocation {
+			return models.ResourceLocation{ResourceBaseEntity: base}
+		})
+}
+
+// NewOrganizationResource creates the aznamingtool_organization resource.
+func NewOrganizationResource() resource.Resource {
+	return newEntityResource("aznamingtool_organization", "organization", apiclient.NewResourceOrganizationService,
+		func(base models.ResourceBaseEntity) models.ResourceOrganization {
+			return models.ResourceOrganization{ResourceBaseEntity: base}
+		})
+}
+
+// NewProjectResource creates the aznamingtool_project resource.
+func NewProjectResource() resource.Resource {
+	return newEntityResource("aznamingtool_project", "project, application or service", apiclient.NewResourceProjectService,
+		func(base models.ResourceBaseEntity) models.ResourceProject {
+			return models.ResourceProject{ResourceBaseEntity: base}
+		})
+}
+
+// NewUnitDeptResource creates the aznamingtool_unit_dept resource.
+func NewUnitDeptResource() resource.Resource {
+	return newEntityResource("aznamingtool_unit_dept", "unit or department", apiclient.NewResourceUnitService,
+		func(base models.ResourceBaseEntity) models.ResourceUnit {
+			return models.ResourceUnit{ResourceBaseEntity: base}
+		})
+}
+
+// EntityResource manages one kind of component value of the Naming Tool, such as environments.
+type EntityResource[T apiclient.Entity] struct {
+	client     *apiclient.APIClient
+	typeName   string
+	kind       string
+	newService func(*apiclient.APIClient) *apiclient.EntityService[T]
+	newEntity  func(models.ResourceBaseEntity) T
+}
+
+// EntityResourceModel describes the resource data model shared by the component values.
+type EntityResourceModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	ShortName types.String `tfsdk:"short_name"`
+	SortOrder types.Int64  `tfsdk:"sort_order"`
+}
+
+// newEntityResource creates a resource managing the component values of the entity service.
+func newEntityResource[T apiclient.Entity](typeName string, kind string, newService func(*apiclient.APIClient) *apiclient.EntityService[T], newEntity func(models.ResourceBaseEntity) T) *EntityResource[T] {
+	return &EntityResource[T]{typeName: typeName, kind: kind, newService: newService, newEntity: newEntity}
+}
+
+// Metadata returns the resource type name.
+func (r *EntityResource[T]) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.typeName
+}
+
+// Schema defines the schema for the resource.
+func (r *EntityResource[T]) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Manages a component value of the %s list of the Naming Tool.", r.kind),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: fmt.Sprintf("The ID of the %s in the Naming Tool.", r.kind),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The name of the %s.", r.kind),
+			},
+			"short_name": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The short name of the %s, used in generated names.", r.kind),
+			},
+			"sort_order": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: fmt.Sprintf("The position of the %s in the lists of the Naming Tool.", r.kind),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the struct.
+func (r *EntityResource[T]) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *apiclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Create handles the creation of the resource.
+func (r *EntityResource[T]) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startOperation(ctx, r.typeName, "Create")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan EntityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client not configured", "The provider client has not been configured.")
+		return
+	}
+
+	created, err := r.newService(r.client).Create(ctx, r.newEntity(plan.toEntity()))
+	if errors.Is(err, utils.ErrConflict) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("short_name"),
+			fmt.Sprintf("Duplicate %s short name", r.kind),
+			fmt.Sprintf("%s. Import the existing %s into this resource or choose another short name.", err, r.kind),
+		)
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Failed to create the %s.", r.kind), err)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Created %s %s", r.kind, plan.ShortName.ValueString()), map[string]interface{}{"id": (*created).BaseEntity().Id})
+	resp.Diagnostics.Append(resp.State.Set(ctx, newEntityResourceModel((*created).BaseEntity()))...)
+}
+
+// Read handles reading the resource data.
+func (r *EntityResource[T]) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startOperation(ctx, r.typeName, "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state EntityResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entity, err := r.newService(r.client).Get(ctx, state.ID.ValueInt64())
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			// The entity was deleted in the Naming Tool, so Terraform has to create it again.
+			tflog.Warn(ctx, fmt.Sprintf("The %s %d no longer exists, removing it from the state", r.kind, state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Failed to read the %s.", r.kind), err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newEntityResourceModel((*entity).BaseEntity()))...)
+}
+
+// Update handles updating the resource.
+func (r *EntityResource[T]) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startOperation(ctx, r.typeName, "Update")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan, state EntityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	base := plan.toEntity()
+	base.Id = state.ID.ValueInt64()
+	updated, err := r.newService(r.client).Update(ctx, r.newEntity(base))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Failed to update the %s.", r.kind), err)
+		return
+	}
+
+	model := newEntityResourceModel((*updated).BaseEntity())
+	if model.ID.ValueInt64() == 0 {
+		// The Naming Tool did not return the entity, so keep the planned values
+		plan.ID = state.ID
+		model = plan
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// Delete handles deleting the resource.
+func (r *EntityResource[T]) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startOperation(ctx, r.typeName, "Delete")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state EntityResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.newService(r.client).Delete(ctx, state.ID.ValueInt64())
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Failed to delete the %s.", r.kind), err)
+	}
+}
+
+// ImportState imports the resource by its ID or its short name.
+func (r *EntityResource[T]) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startOperation(ctx, r.typeName, "ImportState")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	svc := r.newService(r.client)
+	var entity *T
+	var err error
+	if id, parseErr := strconv.ParseInt(req.ID, 10, 64); parseErr == nil {
+		entity, err = svc.Get(ctx, id)
+	} else {
+		entity, err = svc.FindByShortName(ctx, req.ID)
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Could not find the %s %q", r.kind, req.ID), err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, newEntityResourceModel((*entity).BaseEntity()))...)
+}
+
+// toEntity returns the entity fields of the model, without ID. An unknown sort order is sent as zero.
+func (m EntityResourceModel) toEntity() models.ResourceBaseEntity {
+	return models.ResourceBaseEntity{
+		Name:      m.Name.ValueString(),
+		ShortName: m.ShortName.ValueString(),
+		SortOrder: int(m.SortOrder.ValueInt64()),
+	}
+}
+
+// newEntityResourceModel returns the model of an entity returned by the Naming Tool.
+func newEntityResourceModel(entity models.ResourceBaseEntity) EntityResourceModel {
+	return EntityResourceModel{
+		ID:        types.Int64Value(entity.Id),
+		Name:      types.StringValue(entity.Name),
+		ShortName: types.StringValue(entity.ShortName),
+		SortOrder: types.Int64Value(int64(entity.SortOrder)),
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newEnvironmentResource returns the configured aznamingtool_environment resource, its empty
+// state and the Naming Tool behind it, which holds the development environment.
+func newEnvironmentResource(t *testing.T) (resource.ResourceWithImportState, tfsdk.State, *namingtooltest.Server) {
+	server, client := newTestNamingTool(t)
+	server.Put(namingtooltest.Environments, models.ResourceBaseEntity{Id: 1, Name: "Development", ShortName: "dev", SortOrder: 1})
+	r, empty := newTestResource(t, NewEnvironmentResource().(resource.ResourceWithImportState), client)
+	return r, empty, server
+}
+
+// environment returns the environment with id of the Naming Tool.
+func environment(server *namingtooltest.Server, id int64) (models.ResourceBaseEntity, bool) {
+	return namingtooltest.Record[models.ResourceBaseEntity](server, namingtooltest.Environments, id)
+}
+
+func stateModel(t *testing.T, state tfsdk.State) EntityResourceModel {
+	var model EntityResourceModel
+	if diags := state.Get(context.Background(), &model); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	return model
+}
+
+func TestEntityResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newEnvironmentResource(t)
+
+	plan := newTestPlan(t, empty, EntityResourceModel{
+		ID:        types.Int64Unknown(),
+		Name:      types.StringValue("Staging"),
+		ShortName: types.StringValue("stg"),
+		SortOrder: types.Int64Unknown(),
+	})
+	createResp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	created := stateModel(t, createResp.State)
+	assert.Equal(t, int64(2), created.ID.ValueInt64())
+	assert.Equal(t, int64(0), created.SortOrder.ValueInt64())
+
+	// Changes made in the Naming Tool show up on refresh
+	server.Put(namingtooltest.Environments, models.ResourceBaseEntity{Id: 2, Name: "Staging", ShortName: "stage", SortOrder: 3})
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.Equal(t, "stage", stateModel(t, readResp.State).ShortName.ValueString())
+
+	plan = newTestPlan(t, empty, EntityResourceModel{
+		ID:        types.Int64Value(2),
+		Name:      types.StringValue("Staging"),
+		ShortName: types.StringValue("stg"),
+		SortOrder: types.Int64Value(4),
+	})
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	updated, _ := environment(server, 2)
+	assert.Equal(t, models.ResourceBaseEntity{Id: 2, Name: "Staging", ShortName: "stg", SortOrder: 4}, updated)
+
+	deleteResp := resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	_, exists := environment(server, 2)
+	assert.False(t, exists)
+
+	// An entity deleted in the Naming Tool is removed from the state
+	readResp = resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+}
+
+func TestEntityResourceCreateWithPlainTextResponse(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newEnvironmentResource(t)
+	server.PlainTextWrites(namingtooltest.Environments)
+
+	plan := newTestPlan(t, empty, EntityResourceModel{
+		ID:        types.Int64Unknown(),
+		Name:      types.StringValue("Staging"),
+		ShortName: types.StringValue("stg"),
+		SortOrder: types.Int64Value(2),
+	})
+	resp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+	// The created environment is looked up by its short name
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	created := stateModel(t, resp.State)
+	assert.Equal(t, int64(2), created.ID.ValueInt64())
+	assert.Equal(t, int64(2), created.SortOrder.ValueInt64())
+}
+
+func TestEntityResourceCreateRejectsDuplicateShortName(t *testing.T) {
+	ctx := context.Background()
+	r, empty, server := newEnvironmentResource(t)
+	server.PlainTextWrites(namingtooltest.Environments)
+
+	plan := newTestPlan(t, empty, EntityResourceModel{
+		ID:        types.Int64Unknown(),
+		Name:      types.StringValue("Development 2"),
+		ShortName: types.StringValue("DEV"),
+		SortOrder: types.Int64Value(2),
+	})
+	resp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+
+	// The existing environment is neither duplicated nor taken over by the state
+	if assert.True(t, resp.Diagnostics.HasError()) {
+		assert.Equal(t, "Duplicate environment short name", resp.Diagnostics.Errors()[0].Summary())
+	}
+	assert.True(t, resp.State.Raw.IsNull())
+	assert.Equal(t, 0, server.Writes(namingtooltest.Environments))
+}
+
+func TestEntityResourceImportState(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		err  bool
+	}{
+		{name: "by id", id: "1"},
+		{name: "by short name", id: "DEV"},
+		{name: "unknown id", id: "7", err: true},
+		{name: "unknown short name", id: "tst", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, empty, _ := newEnvironmentResource(t)
+			resp := resource.ImportStateResponse{State: empty}
+			r.ImportState(context.Background(), resource.ImportStateRequest{ID: tt.id}, &resp)
+
+			assert.Equal(t, tt.err, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if !tt.err {
+				imported := stateModel(t, resp.State)
+				assert.Equal(t, int64(1), imported.ID.ValueInt64())
+				assert.Equal(t, "Development", imported.Name.ValueString())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+)
+
+// checkInstanceHealth probes the Naming Tool instances of base_urls and warns about the
+// instances that are unavailable, since requests go to the other instances meanwhile.
+func checkInstanceHealth(ctx context.Context, client *apiclient.APIClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, instance := range client.CheckHealth(ctx) {
+		if instance.Healthy {
+			tflog.Info(ctx, "Naming Tool instance is healthy", map[string]interface{}{"base_url": instance.BaseURL})
+			continue
+		}
+		diags.AddWarning(
+			"Naming Tool instance unavailable",
+			fmt.Sprintf("The Naming Tool at %s failed the health check: %s. Requests are sent to the other instances of base_urls until it recovers.", instance.BaseURL, instance.Err),
+		)
+	}
+	return diags
+}
+
+// reportServingInstance logs which Naming Tool instance generated a name, and warns when
+// it was not the first instance of base_urls.
+func reportServingInstance(ctx context.Context, client *apiclient.APIClient, baseURL string, name string, diags *diag.Diagnostics) {
+	tflog.Info(ctx, "Naming Tool instance generated the name", map[string]interface{}{"base_url": baseURL, "name": name})
+
+	if baseURL != "" && baseURL != client.BaseURL {
+		diags.AddWarning(
+			"Name generated by a standby Naming Tool",
+			fmt.Sprintf("The name %q was generated by the Naming Tool at %s because the Naming Tool at %s was unavailable.", name, baseURL, client.BaseURL),
+		)
+	}
+}
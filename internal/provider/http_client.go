@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+)
+
+const (
+	// defaultConnectTimeout bounds establishing the TCP connection and the TLS handshake.
+	defaultConnectTimeout = 30 * time.Second
+	// defaultResponseTimeout bounds waiting for the response headers once the request was sent.
+	defaultResponseTimeout = 60 * time.Second
+)
+
+// TLSModel describes the tls block of the provider configuration.
+type TLSModel struct {
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+}
+
+// buildHTTPClient creates the HTTP client used by the API client from the provider configuration.
+// Unset settings keep the behaviour of http.DefaultTransport, including proxies taken from
+// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func buildHTTPClient(config *AzureNamingToolProviderModel) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	connectTimeout := defaultConnectTimeout
+	if !config.ConnectTimeout.IsNull() {
+		connectTimeout = parseDurationAttribute(config.ConnectTimeout, path.Root("connect_timeout"), &diags)
+	}
+	responseTimeout := defaultResponseTimeout
+	if !config.ResponseTimeout.IsNull() {
+		responseTimeout = parseDurationAttribute(config.ResponseTimeout, path.Root("response_timeout"), &diags)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = connectTimeout
+	transport.ResponseHeaderTimeout = responseTimeout
+
+	if !config.ProxyUrl.IsNull() {
+		proxyURL, err := url.Parse(config.ProxyUrl.ValueString())
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid proxy URL",
+				fmt.Sprintf("The value %q is not an absolute URL such as \"http://proxy.example.com:8080\".", config.ProxyUrl.ValueString()),
+			)
+		} else {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	if config.TLS != nil {
+		tlsConfig, tlsDiags := config.TLS.toTLSConfig()
+		diags.Append(tlsDiags...)
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{Transport: transport}, diags
+}
+
+// wrapRecorder returns a copy of httpClient that records requests to a cassette file or
+// replays them from it, when the AZ_NAMINGTOOL_RECORDER_MODE environment variable is set.
+// The secrets are scrubbed from the recorded interactions.
+func wrapRecorder(httpClient *http.Client, secrets ...string) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	transport, err := apiclient.RecorderFromEnv(httpClient.Transport, secrets...)
+	if err != nil {
+		diags.AddError("Invalid recorder settings", err.Error())
+		return httpClient, diags
+	}
+	if transport != httpClient.Transport {
+		diags.AddWarning(
+			"Naming Tool requests are recorded or replayed",
+			fmt.Sprintf("%s is set, so requests use the cassette %s. Unset it to use the Naming Tool directly.", apiclient.RecorderModeEnv, os.Getenv(apiclient.RecorderCassetteEnv)),
+		)
+	}
+
+	wrapped := *httpClient
+	wrapped.Transport = transport
+	return &wrapped, diags
+}
+
+// toTLSConfig builds the TLS configuration for the CA bundle and client certificate of the tls block.
+func (m *TLSModel) toTLSConfig() (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	caPEM, caPath := readPEMAttribute(m.CACertFile, m.CACertPEM, "ca_cert_file", "ca_cert_pem", &diags)
+	if caPEM != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			diags.AddAttributeError(caPath, "Invalid CA bundle", "The CA bundle does not contain any PEM encoded certificate.")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certPEM, certPath := readPEMAttribute(m.ClientCertFile, m.ClientCertPEM, "client_cert_file", "client_cert_pem", &diags)
+	keyPEM, keyPath := readPEMAttribute(m.ClientKeyFile, m.ClientKeyPEM, "client_key_file", "client_key_pem", &diags)
+	switch {
+	case certPEM != nil && keyPEM != nil:
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			diags.AddAttributeError(certPath, "Invalid client certificate", fmt.Sprintf("The client certificate and key cannot be loaded: %s", err))
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case certPEM != nil:
+		diags.AddAttributeError(certPath, "Missing client key", "A client certificate requires client_key_file or client_key_pem.")
+	case keyPEM != nil:
+		diags.AddAttributeError(keyPath, "Missing client certificate", "A client key requires client_cert_file or client_cert_pem.")
+	}
+
+	if m.InsecureSkipVerify.ValueBool() {
+		tlsConfig.InsecureSkipVerify = true
+		diags.AddAttributeWarning(
+			path.Root("tls").AtName("insecure_skip_verify"),
+			"TLS verification disabled",
+			"The Naming Tool certificate is not verified. Only use this setting in lab environments.",
+		)
+	}
+
+	return tlsConfig, diags
+}
+
+// readPEMAttribute returns the PEM content configured either as a file path or inline,
+// together with the path of the attribute it came from.
+func readPEMAttribute(file types.String, pem types.String, fileAttribute string, pemAttribute string, diags *diag.Diagnostics) ([]byte, path.Path) {
+	filePath := path.Root("tls").AtName(fileAttribute)
+	pemPath := path.Root("tls").AtName(pemAttribute)
+
+	if !file.IsNull() && !pem.IsNull() {
+		diags.AddAttributeError(pemPath, "Conflicting TLS settings", fmt.Sprintf("Only one of %s and %s can be set.", fileAttribute, pemAttribute))
+		return nil, pemPath
+	}
+	if !pem.IsNull() {
+		return []byte(pem.ValueString()), pemPath
+	}
+	if !file.IsNull() {
+		content, err := os.ReadFile(file.ValueString())
+		if err != nil {
+			diags.AddAttributeError(filePath, "Cannot read file", err.Error())
+			return nil, filePath
+		}
+		return content, filePath
+	}
+	return nil, filePath
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHTTPClientTrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	untrusted, diags := buildHTTPClient(&AzureNamingToolProviderModel{})
+	assert.False(t, diags.HasError())
+	_, err := untrusted.Get(server.URL)
+	assert.Error(t, err)
+
+	trusted, diags := buildHTTPClient(&AzureNamingToolProviderModel{
+		TLS: &TLSModel{CACertPEM: types.StringValue(string(caPEM))},
+	})
+	assert.False(t, diags.HasError())
+	resp, err := trusted.Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+}
+
+func TestBuildHTTPClientInvalidSettings(t *testing.T) {
+	_, diags := buildHTTPClient(&AzureNamingToolProviderModel{
+		ProxyUrl:       types.StringValue("proxy:8080"),
+		ConnectTimeout: types.StringValue("soon"),
+		TLS: &TLSModel{
+			CACertPEM:      types.StringValue("not a certificate"),
+			ClientCertFile: types.StringValue("/does/not/exist.pem"),
+		},
+	})
+
+	assert.Equal(t, 4, diags.ErrorsCount())
+}
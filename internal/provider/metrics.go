@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+)
+
+var (
+	// metricsClients are the clients of the configured providers that write a metrics file.
+	metricsClients   []*apiclient.APIClient
+	metricsClientsMu sync.Mutex
+)
+
+// registerMetricsClient makes endOperation flush the metrics of client.
+func registerMetricsClient(client *apiclient.APIClient) {
+	metricsClientsMu.Lock()
+	defer metricsClientsMu.Unlock()
+	metricsClients = append(metricsClients, client)
+}
+
+// flushMetrics writes the metrics files of the configured providers whose clients sent
+// requests since the last flush.
+func flushMetrics(ctx context.Context) {
+	metricsClientsMu.Lock()
+	clients := append([]*apiclient.APIClient(nil), metricsClients...)
+	metricsClientsMu.Unlock()
+
+	for _, client := range clients {
+		client.FlushMetrics(ctx)
+	}
+}
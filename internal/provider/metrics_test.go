@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndOperationWritesMetrics(t *testing.T) {
+	ctx := context.Background()
+	server := namingtooltest.NewServer(t)
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	client, err := apiclient.NewAPIClient(server.URL, "key", "admin", server.Client(), apiclient.WithMetricsFile(path))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	registerMetricsClient(client)
+	t.Cleanup(func() { metricsClients = nil })
+
+	// The requests of an operation are written when it ends, without closing the client
+	opCtx, span := startOperation(ctx, "aznamingtool_environment", "Read")
+	_, err = apiclient.NewResourceEnvironmentService(client).List(opCtx)
+	assert.NoError(t, err)
+	endOperation(opCtx, span, &diag.Diagnostics{})
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `aznamingtool_requests_total{endpoint="GetAllResourceEnvironments",status="200"} 1`)
+}
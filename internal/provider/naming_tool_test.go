@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+)
+
+// newTestNamingTool starts an in-memory Naming Tool and returns it with a client of it, both
+// closed when the test ends.
+func newTestNamingTool(t *testing.T) (*namingtooltest.Server, *apiclient.APIClient) {
+	server := namingtooltest.NewServer(t)
+	client, err := apiclient.NewAPIClient(server.URL, "key", "admin", server.Client())
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+// newTestResource configures r with client, unless it is nil, and returns it with an empty
+// state of its schema.
+func newTestResource[R resource.Resource](t *testing.T, r R, client *apiclient.APIClient) (R, tfsdk.State) {
+	ctx := context.Background()
+	if configurable, ok := resource.Resource(r).(resource.ResourceWithConfigure); ok && client != nil {
+		var resp resource.ConfigureResponse
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("failed to configure resource: %v", resp.Diagnostics)
+		}
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	return r, tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+// newTestPlan returns a plan of the schema of empty holding model.
+func newTestPlan(t *testing.T, empty tfsdk.State, model interface{}) tfsdk.Plan {
+	plan := tfsdk.Plan{Schema: empty.Schema, Raw: empty.Raw}
+	if diags := plan.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("failed to set plan: %v", diags)
+	}
+	return plan
+}
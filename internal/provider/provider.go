@@ -2,9 +2,14 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -12,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
 )
 
 var (
@@ -31,9 +37,39 @@ type AzureNamingToolProvider struct {
 }
 
 type AzureNamingToolProviderModel struct {
-	ApiKey       types.String `tfsdk:"api_key"`
-	BaseUrl      types.String `tfsdk:"base_url"`
-	AdminPassord types.String `tfsdk:"admin_password"`
+	ApiKey              types.String  `tfsdk:"api_key"`
+	BaseUrl             types.String  `tfsdk:"base_url"`
+	AdminPassord        types.String  `tfsdk:"admin_password"`
+	Retry               *RetryModel   `tfsdk:"retry"`
+	Parallelism         types.Int64   `tfsdk:"parallelism"`
+	MaxQueueDepth       types.Int64   `tfsdk:"max_queue_depth"`
+	QueueTimeout        types.String  `tfsdk:"queue_timeout"`
+	RequestsPerSecond   types.Float64 `tfsdk:"requests_per_second"`
+	Burst               types.Int64   `tfsdk:"burst"`
+	ProxyUrl            types.String  `tfsdk:"proxy_url"`
+	ConnectTimeout      types.String  `tfsdk:"connect_timeout"`
+	ResponseTimeout     types.String  `tfsdk:"response_timeout"`
+	TLS                 *TLSModel     `tfsdk:"tls"`
+	BearerToken         types.String  `tfsdk:"bearer_token"`
+	OAuth2              *OAuth2Model  `tfsdk:"oauth2"`
+	LogHttpBodies       types.Bool    `tfsdk:"log_http_bodies"`
+	CacheTtl            types.String  `tfsdk:"cache_ttl"`
+	ServerVersion       types.String  `tfsdk:"server_version"`
+	MetricsFile         types.String  `tfsdk:"metrics_file"`
+	BaseUrls            types.List    `tfsdk:"base_urls"`
+	HealthCheckInterval types.String  `tfsdk:"health_check_interval"`
+	CreatedBy           types.String  `tfsdk:"created_by"`
+	NameAdoptionWindow  types.String  `tfsdk:"name_adoption_window"`
+}
+
+// RetryModel describes the retry block of the provider configuration.
+type RetryModel struct {
+	MaxAttempts             types.Int64  `tfsdk:"max_attempts"`
+	MinWait                 types.String `tfsdk:"min_wait"`
+	MaxWait                 types.String `tfsdk:"max_wait"`
+	Jitter                  types.Bool   `tfsdk:"jitter"`
+	RetryableStatusCodes    types.List   `tfsdk:"retryable_status_codes"`
+	RetryOnConnectionErrors types.Bool   `tfsdk:"retry_on_connection_errors"`
 }
 
 func (p *AzureNamingToolProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -42,6 +78,15 @@ func (p *AzureNamingToolProvider) Schema(_ context.Context, _ provider.SchemaReq
 			"base_url": schema.StringAttribute{
 				Optional: true,
 			},
+			"base_urls": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The base URLs of Naming Tool instances sharing the same storage, in order of preference. Requests go to the first healthy instance. Conflicts with base_url.",
+			},
+			"health_check_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long an instance of base_urls that failed is skipped before it is probed again, as a duration string such as \"30s\".",
+			},
 			"api_key": schema.StringAttribute{
 				Optional:  true,
 				Sensitive: true,
@@ -50,6 +95,161 @@ func (p *AzureNamingToolProvider) Schema(_ context.Context, _ provider.SchemaReq
 				Optional:  true,
 				Sensitive: true,
 			},
+			"bearer_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A static OAuth2 access token sent in the Authorization header, for a Naming Tool behind App Service Authentication.",
+			},
+			"oauth2": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Obtains the access token sent in the Authorization header through the OAuth2 client credentials grant, e.g. from Entra ID.",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						Optional:    true,
+						Description: "The token endpoint. Conflicts with tenant_id.",
+					},
+					"tenant_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The Entra ID tenant, used to build the token endpoint. Conflicts with token_url.",
+					},
+					"client_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The client ID of the application requesting the token.",
+					},
+					"client_secret": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The client secret of the application requesting the token. Defaults to the AZ_NAMINGTOOL_CLIENT_SECRET environment variable.",
+					},
+					"scopes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The scopes requested, such as \"api://<naming tool app id>/.default\".",
+					},
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of read requests sent to the Naming Tool concurrently. Name generation and other writes always run one at a time.",
+			},
+			"max_queue_depth": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of requests that can wait in each queue before new requests have to wait for a free slot.",
+			},
+			"queue_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long a request waits for a free queue slot before failing, as a duration string such as \"5m\".",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The average number of requests per second sent to the Naming Tool. Requests are not rate limited when unset.",
+			},
+			"burst": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of requests that can be sent at once before requests_per_second applies. Defaults to requests_per_second rounded up.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the proxy used to reach the Naming Tool. Defaults to the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.",
+			},
+			"connect_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long establishing the connection and the TLS handshake may take, as a duration string such as \"30s\".",
+			},
+			"response_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for the Naming Tool to answer a request once it was sent, as a duration string such as \"60s\".",
+			},
+			"tls": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Controls how the Naming Tool certificate is verified and which client certificate is presented.",
+				Attributes: map[string]schema.Attribute{
+					"ca_cert_file": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path of a PEM encoded CA bundle trusted in addition to the system roots.",
+					},
+					"ca_cert_pem": schema.StringAttribute{
+						Optional:    true,
+						Description: "A PEM encoded CA bundle trusted in addition to the system roots.",
+					},
+					"client_cert_file": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path of the PEM encoded client certificate used for mutual TLS.",
+					},
+					"client_key_file": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path of the PEM encoded private key of the client certificate.",
+					},
+					"client_cert_pem": schema.StringAttribute{
+						Optional:    true,
+						Description: "The PEM encoded client certificate used for mutual TLS.",
+					},
+					"client_key_pem": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The PEM encoded private key of the client certificate.",
+					},
+					"insecure_skip_verify": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Disables the verification of the Naming Tool certificate. Only use this setting in lab environments.",
+					},
+				},
+			},
+			"cache_ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long reference data such as resource types and locations is cached, as a duration string such as \"5m\". Set to \"0s\" to disable the cache.",
+			},
+			"server_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "The version of the Naming Tool, such as \"4.2.1\". The version is detected from the Naming Tool when unset, and a warning is reported if the Naming Tool does not report it.",
+			},
+			"created_by": schema.StringAttribute{
+				Optional:    true,
+				Description: "The creator recorded in the generated names log for the names requested by the provider. Defaults to \"Terraform\".",
+			},
+			"name_adoption_window": schema.StringAttribute{
+				Optional:    true,
+				Description: "Enables adopting the name stored by a name request that failed without an answer, and sets how old that name may be, as a duration string such as \"15m\". Disabled by default.",
+			},
+			"metrics_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a file the request metrics are written to in OpenMetrics text format, rewritten at the end of every Terraform operation that sent requests.",
+			},
+			"log_http_bodies": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Writes request and response bodies to the API log, enabled with TF_LOG_PROVIDER_AZNAMINGTOOL_API=DEBUG. Secrets are masked.",
+			},
+			"retry": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Controls how failed requests to the Naming Tool are retried.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The total number of attempts per request, including the first one. Set to 1 to disable retries.",
+					},
+					"min_wait": schema.StringAttribute{
+						Optional:    true,
+						Description: "The wait before the first retry, as a duration string such as \"1s\".",
+					},
+					"max_wait": schema.StringAttribute{
+						Optional:    true,
+						Description: "The upper bound for any single wait, including waits requested through Retry-After.",
+					},
+					"jitter": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Randomises each wait to spread out concurrent retries.",
+					},
+					"retryable_status_codes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.Int64Type,
+						Description: "The response status codes that are retried.",
+					},
+					"retry_on_connection_errors": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Retries requests that failed before a response was received.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -60,6 +260,10 @@ func (p *AzureNamingToolProvider) Metadata(_ context.Context, _ provider.Metadat
 }
 
 func (p *AzureNamingToolProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.Diagnostics.Append(setupTracing(ctx, p.version)...)
+	ctx, span := startOperation(ctx, "azurenaming", "Configure")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
 	var config AzureNamingToolProviderModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -82,6 +286,13 @@ func (p *AzureNamingToolProvider) Configure(ctx context.Context, req provider.Co
 			"The provider cannot create the API client as there is an unknown configuration value for the base url. ",
 		)
 	}
+	if config.BaseUrls.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_urls"),
+			"Unknown Base Urls value",
+			"The provider cannot create the API client as there is an unknown configuration value for the base urls. ",
+		)
+	}
 	if config.AdminPassord.IsUnknown() {
 		resp.Diagnostics.AddAttributeWarning(
 			path.Root("admin_password"),
@@ -102,14 +313,169 @@ func (p *AzureNamingToolProvider) Configure(ctx context.Context, req provider.Co
 		api_key = config.ApiKey.ValueString()
 	}
 
+	baseURLPath := path.Root("base_url")
+	var failoverURLs []string
+	if !config.BaseUrls.IsNull() && !config.BaseUrls.IsUnknown() {
+		baseURLPath = path.Root("base_urls")
+		var baseURLs []string
+		resp.Diagnostics.Append(config.BaseUrls.ElementsAs(ctx, &baseURLs, false)...)
+		switch {
+		case !config.BaseUrl.IsNull():
+			resp.Diagnostics.AddAttributeError(baseURLPath, "Conflicting Base Url values", "Set either base_url or base_urls, not both.")
+		case len(baseURLs) == 0:
+			resp.Diagnostics.AddAttributeError(baseURLPath, "Invalid Base Urls value", "The base_urls list must contain at least one URL.")
+		default:
+			base_url = baseURLs[0]
+			failoverURLs = baseURLs[1:]
+		}
+	}
+
 	if !config.AdminPassord.IsNull() {
 		admin_password = config.AdminPassord.ValueString()
 	}
+	retryPolicy := apiclient.DefaultRetryPolicy()
+	if config.Retry != nil {
+		resp.Diagnostics.Append(config.Retry.apply(ctx, &retryPolicy)...)
+	}
+
+	clientOptions := []apiclient.ClientOption{}
+	if !config.Parallelism.IsNull() {
+		if config.Parallelism.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("parallelism"),
+				"Invalid parallelism",
+				"The parallelism must be at least 1.",
+			)
+		}
+		clientOptions = append(clientOptions, apiclient.WithParallelism(int(config.Parallelism.ValueInt64())))
+	}
+	if !config.MaxQueueDepth.IsNull() {
+		if config.MaxQueueDepth.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_queue_depth"),
+				"Invalid queue depth",
+				"The queue depth must be at least 1.",
+			)
+		}
+		clientOptions = append(clientOptions, apiclient.WithQueueDepth(int(config.MaxQueueDepth.ValueInt64())))
+	}
+	if !config.QueueTimeout.IsNull() {
+		timeout := parseDurationAttribute(config.QueueTimeout, path.Root("queue_timeout"), &resp.Diagnostics)
+		clientOptions = append(clientOptions, apiclient.WithQueueTimeout(timeout))
+	}
+
+	if !config.RequestsPerSecond.IsNull() {
+		if config.RequestsPerSecond.ValueFloat64() <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid request rate",
+				"The number of requests per second must be greater than 0.",
+			)
+		}
+		if !config.Burst.IsNull() && config.Burst.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("burst"),
+				"Invalid burst",
+				"The burst must be at least 1.",
+			)
+		}
+		clientOptions = append(clientOptions, apiclient.WithRateLimit(config.RequestsPerSecond.ValueFloat64(), int(config.Burst.ValueInt64())))
+	} else if !config.Burst.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("burst"),
+			"Burst without request rate",
+			"The burst is ignored because requests_per_second is not set.",
+		)
+	}
+
+	cacheTTL := apiclient.DefaultCacheTTL
+	if !config.CacheTtl.IsNull() {
+		cacheTTL = parseDurationAttribute(config.CacheTtl, path.Root("cache_ttl"), &resp.Diagnostics)
+	}
+	clientOptions = append(clientOptions, apiclient.WithCache(cacheTTL))
+
+	if !config.ServerVersion.IsNull() {
+		clientOptions = append(clientOptions, apiclient.WithServerVersion(parseServerVersion(config.ServerVersion, &resp.Diagnostics)))
+	}
+
+	if len(failoverURLs) > 0 {
+		clientOptions = append(clientOptions, apiclient.WithFailoverURLs(failoverURLs...))
+	}
+	if !config.HealthCheckInterval.IsNull() {
+		interval := parseDurationAttribute(config.HealthCheckInterval, path.Root("health_check_interval"), &resp.Diagnostics)
+		clientOptions = append(clientOptions, apiclient.WithHealthCheckInterval(interval))
+	}
+
+	if !config.CreatedBy.IsNull() {
+		clientOptions = append(clientOptions, apiclient.WithCreatedBy(config.CreatedBy.ValueString()))
+	}
+	if !config.NameAdoptionWindow.IsNull() {
+		window := parseDurationAttribute(config.NameAdoptionWindow, path.Root("name_adoption_window"), &resp.Diagnostics)
+		clientOptions = append(clientOptions, apiclient.WithAdoptionWindow(window))
+	}
+
+	if !config.MetricsFile.IsNull() && config.MetricsFile.ValueString() != "" {
+		clientOptions = append(clientOptions, apiclient.WithMetricsFile(config.MetricsFile.ValueString()))
+	}
+
+	if config.LogHttpBodies.ValueBool() {
+		clientOptions = append(clientOptions, apiclient.WithBodyLogging(true))
+	}
+
+	httpClient, diags := buildHTTPClient(&config)
+	resp.Diagnostics.Append(diags...)
+
+	authenticator, diags := buildAuthenticator(ctx, &config, api_key, admin_password, httpClient)
+	resp.Diagnostics.Append(diags...)
+	clientOptions = append(clientOptions, apiclient.WithAuthenticator(authenticator))
+
+	// The token requests of the authenticator are not recorded, only the Naming Tool requests
+	httpClient, diags = wrapRecorder(httpClient, api_key, admin_password)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Logging
 	tflog.Info(ctx, "Configuring ApiClient")
 
 	// Example of configuring the client
-	client := apiclient.NewAPIClient(base_url, api_key, admin_password, nil)
+	client, err := apiclient.NewAPIClient(base_url, api_key, admin_password, httpClient, clientOptions...)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidBaseURL):
+			resp.Diagnostics.AddAttributeError(
+				baseURLPath,
+				"Invalid Base Url value",
+				fmt.Sprintf("Set base_url or the AZ_NAMINGTOOL_BASEURL environment variable to the URL of the Naming Tool: %s", err),
+			)
+		case errors.Is(err, utils.ErrMissingAPIKey):
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key"),
+				"Missing Api Key value",
+				"Set api_key or the AZ_NAMINGTOOL_APIKEY environment variable to the API key of the Naming Tool.",
+			)
+		default:
+			resp.Diagnostics.AddError("Failed to create the API client", err.Error())
+		}
+		return
+	}
+	client.RetryPolicy = retryPolicy
+
+	if len(failoverURLs) > 0 {
+		resp.Diagnostics.Append(checkInstanceHealth(ctx, client)...)
+	}
+	resp.Diagnostics.Append(checkServerVersion(ctx, client)...)
+	if resp.Diagnostics.HasError() {
+		client.Close()
+		return
+	}
+
+	if client.MetricsFile != "" {
+		registerMetricsClient(client)
+	}
+
 	// Make the client available during DataSource and Resource type Configure methods
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -119,11 +485,88 @@ func (p *AzureNamingToolProvider) Configure(ctx context.Context, req provider.Co
 func (p *AzureNamingToolProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewResourceNameDataSource,
+		NewServerInfoDataSource,
 	}
 }
 
 func (p *AzureNamingToolProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAzureNameResource,
+		NewComponentResource,
+		NewCustomComponentResource,
+		NewDelimitersResource,
+		NewEnvironmentResource,
+		NewFunctionResource,
+		NewLocationResource,
+		NewOrganizationResource,
+		NewProjectResource,
+		NewResourceTypeSettingsResource,
+		NewUnitDeptResource,
+	}
+}
+
+// apply overrides the fields of policy that are set in the retry block.
+func (m *RetryModel) apply(ctx context.Context, policy *apiclient.RetryPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !m.MaxAttempts.IsNull() {
+		policy.MaxAttempts = int(m.MaxAttempts.ValueInt64())
+		if policy.MaxAttempts < 1 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("max_attempts"),
+				"Invalid retry attempts",
+				"The number of attempts must be at least 1.",
+			)
+		}
+	}
+	if !m.MinWait.IsNull() {
+		policy.MinWait = parseDurationAttribute(m.MinWait, path.Root("retry").AtName("min_wait"), &diags)
+	}
+	if !m.MaxWait.IsNull() {
+		policy.MaxWait = parseDurationAttribute(m.MaxWait, path.Root("retry").AtName("max_wait"), &diags)
+	}
+	if policy.MaxWait < policy.MinWait {
+		diags.AddAttributeError(
+			path.Root("retry").AtName("max_wait"),
+			"Invalid retry wait",
+			"The maximum wait cannot be shorter than the minimum wait.",
+		)
+	}
+	if !m.Jitter.IsNull() {
+		policy.Jitter = m.Jitter.ValueBool()
+	}
+	if !m.RetryableStatusCodes.IsNull() {
+		var codes []int64
+		diags.Append(m.RetryableStatusCodes.ElementsAs(ctx, &codes, false)...)
+		policy.RetryableStatusCodes = make([]int, 0, len(codes))
+		for _, code := range codes {
+			if code < http.StatusBadRequest || code > 599 {
+				diags.AddAttributeError(
+					path.Root("retry").AtName("retryable_status_codes"),
+					"Invalid status code",
+					fmt.Sprintf("%d is not an HTTP error status code.", code),
+				)
+				continue
+			}
+			policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, int(code))
+		}
+	}
+	if !m.RetryOnConnectionErrors.IsNull() {
+		policy.RetryTransportErrors = m.RetryOnConnectionErrors.ValueBool()
+	}
+
+	return diags
+}
+
+// parseDurationAttribute parses a duration string attribute, adding an attribute error on failure.
+func parseDurationAttribute(value types.String, attributePath path.Path, diags *diag.Diagnostics) time.Duration {
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid duration",
+			fmt.Sprintf("The value %q is not a valid duration, use values such as \"500ms\", \"10s\" or \"1m\".", value.ValueString()),
+		)
 	}
+	return duration
 }
@@ -87,6 +87,9 @@ func (d *ResourceNameDataSource) Configure(_ context.Context, req datasource.Con
 
 // Read handles reading the data source data.
 func (d *ResourceNameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_name", "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
 	var state ResourceNameDataSourceModel
 	diags := req.Config.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -100,9 +103,9 @@ func (d *ResourceNameDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	svc := apiclient.NewResourceNamingService(d.client)
-	resource, err := svc.GetGeneratedName(state.ID.String())
+	resource, err := svc.GetGeneratedName(ctx, state.ID.String())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading resource", err)
 		diags = resp.State.Set(ctx, state)
 		resp.Diagnostics.Append(diags...)
 		return
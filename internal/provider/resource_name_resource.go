@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -112,6 +113,9 @@ func (r *AzureNameResource) Configure(_ context.Context, req resource.ConfigureR
 
 // Create handles the creation of the resource.
 func (r *AzureNameResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_name", "Create")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
 	var plan AzureNameResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -131,19 +135,28 @@ func (r *AzureNameResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	svc := apiclient.NewResourceNamingService(r.client)
-	result, err := svc.RequestName(request)
+	requestCtx, servedBy := apiclient.TrackInstance(ctx)
+	result, adopted, err := svc.RequestNameOnce(requestCtx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to request the name.", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to request the name.", err)
 		return
 	}
-	plan.ID = types.Int64Value(result.ResourceNameDetails.Id)
-	newPlan, err := _ReadFromAPI(r.client, strconv.FormatInt(result.ResourceNameDetails.Id, 10))
+	if adopted {
+		resp.Diagnostics.AddWarning(
+			"Adopted an existing generated name",
+			fmt.Sprintf("The name %q (ID %d) was stored on %s by the request that failed without an answer from the Naming Tool, so it was adopted instead of generating a duplicate.", result.ResourceName, result.Id, result.CreatedOn),
+		)
+	} else {
+		reportServingInstance(ctx, r.client, servedBy(), result.ResourceName, &resp.Diagnostics)
+	}
+	plan.ID = types.Int64Value(result.Id)
+	newPlan, err := _ReadFromAPI(ctx, r.client, strconv.FormatInt(result.Id, 10))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read the resource.", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to read the resource.", err)
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Resource created successfully(%s). %d", request.ResourceType, newPlan))
+	tflog.Info(ctx, fmt.Sprintf("Resource created successfully(%s). %d", request.ResourceType, newPlan.ID.ValueInt64()))
 
 	if request.ResourceId != 0 {
 		newPlan.ResourceTypeId = types.Int64Value(request.ResourceId)
@@ -153,9 +166,9 @@ func (r *AzureNameResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 // _ReadFromAPI fetches and transforms the API response into the schema model.
-func _ReadFromAPI(client *apiclient.APIClient, id string) (*AzureNameResourceModel, error) {
+func _ReadFromAPI(ctx context.Context, client *apiclient.APIClient, id string) (*AzureNameResourceModel, error) {
 	svc := apiclient.NewResourceNamingService(client)
-	result, err := svc.GetGeneratedName(id)
+	result, err := svc.GetGeneratedName(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +177,9 @@ func _ReadFromAPI(client *apiclient.APIClient, id string) (*AzureNameResourceMod
 
 // Read handles reading the resource data.
 func (r *AzureNameResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_name", "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
 	var state AzureNameResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -171,22 +187,50 @@ func (r *AzureNameResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	plan, err := _ReadFromAPI(r.client, state.ID.String())
+	plan, err := _ReadFromAPI(ctx, r.client, state.ID.String())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read the resource.", err.Error())
+		if errors.Is(err, utils.ErrNotFound) {
+			// The name was deleted in the Naming Tool, so Terraform has to create a new one.
+			tflog.Warn(ctx, fmt.Sprintf("Generated name %s no longer exists, removing it from the state", state.ID.String()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Failed to read the resource.", err)
 		return
 	}
 
+	// The resource type ID is only known from the configuration.
+	plan.ResourceTypeId = state.ResourceTypeId
+
+	if !state.ResourceName.IsNull() && !state.ResourceName.Equal(plan.ResourceName) {
+		resp.Diagnostics.AddWarning(
+			"Generated name changed outside of Terraform",
+			fmt.Sprintf("The name with ID %s is now %q in the Naming Tool, but was %q when Terraform last stored it.", state.ID.String(), plan.ResourceName.ValueString(), state.ResourceName.ValueString()),
+		)
+	}
+	if !state.Components.IsNull() && !state.Components.Equal(plan.Components) {
+		resp.Diagnostics.AddWarning(
+			"Name components changed outside of Terraform",
+			fmt.Sprintf("The components of the name with ID %s no longer match the stored components. Terraform will propose to replace the name.", state.ID.String()),
+		)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 // Update handles updating the resource.
 func (r *AzureNameResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_name", "Update")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
 	resp.Diagnostics.AddError("Update not supported", "This resource does not support updates.")
 }
 
 // Delete handles deleting the resource.
 func (r *AzureNameResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_name", "Delete")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
 	var state AzureNameResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -195,15 +239,18 @@ func (r *AzureNameResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 
 	svc := apiclient.NewResourceNamingService(r.client)
-	err := svc.DeleteGeneratedName(state.ID.String())
+	err := svc.DeleteGeneratedName(ctx, state.ID.String())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete the generated name.", err.Error())
+		addAPIError(&resp.Diagnostics, "Failed to delete the generated name.", err)
 		return
 	}
 }
 
 // ImportState handles importing the resource state.
 func (r *AzureNameResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_name", "ImportState")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
 	// The ID for the resource is expected to be passed in req.ID
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
@@ -215,12 +262,9 @@ func (r *AzureNameResource) ImportState(ctx context.Context, req resource.Import
 	}
 
 	// Fetch the resource from the API
-	resourceModel, err := _ReadFromAPI(r.client, strconv.FormatInt(id, 10))
+	resourceModel, err := _ReadFromAPI(ctx, r.client, strconv.FormatInt(id, 10))
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error fetching resource",
-			fmt.Sprintf("Could not fetch resource with ID '%s': %s", req.ID, err.Error()),
-		)
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Could not fetch resource with ID '%s'", req.ID), err)
 		return
 	}
 
@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &ResourceTypeSettingsResource{}
+	_ resource.ResourceWithConfigure      = &ResourceTypeSettingsResource{}
+	_ resource.ResourceWithImportState    = &ResourceTypeSettingsResource{}
+	_ resource.ResourceWithValidateConfig = &ResourceTypeSettingsResource{}
+)
+
+// NewResourceTypeSettingsResource creates the aznamingtool_resource_type_settings resource.
+func NewResourceTypeSettingsResource() resource.Resource {
+	return &ResourceTypeSettingsResource{}
+}
+
+// ResourceTypeSettingsResource manages the naming settings of a resource type of the Naming Tool.
+type ResourceTypeSettingsResource struct {
+	client *apiclient.APIClient
+}
+
+// ResourceTypeSettingsResourceModel describes the resource data model.
+type ResourceTypeSettingsResourceModel struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	ResourceTypeId     types.Int64  `tfsdk:"resource_type_id"`
+	Resource           types.String `tfsdk:"resource"`
+	ShortName          types.String `tfsdk:"short_name"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	ApplyDelimiter     types.Bool   `tfsdk:"apply_delimiter"`
+	OptionalComponents types.Set    `tfsdk:"optional_components"`
+	ExcludedComponents types.Set    `tfsdk:"excluded_components"`
+}
+
+// Metadata returns the resource type name.
+func (r *ResourceTypeSettingsResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "aznamingtool_resource_type_settings"
+}
+
+// Schema defines the schema for the resource.
+func (r *ResourceTypeSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	components := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{
+			ElementType:   types.StringType,
+			Optional:      true,
+			Computed:      true,
+			Description:   description,
+			PlanModifiers: []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the naming settings of a resource type of the Naming Tool. Settings left out keep their value in the Naming Tool.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:      true,
+				Description:   "The ID of the resource type in the Naming Tool.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"resource_type_id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the resource type. Exactly one of resource_type_id and resource must be set.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIfConfigured(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The resource of the resource type, such as Storage/storageAccounts. Exactly one of resource_type_id and resource must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"short_name": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "The short name of the resource type, used in generated names.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether names can be generated for the resource type.",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"apply_delimiter": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether generated names of the resource type contain the delimiter.",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"optional_components": components("The components that may be left out of the names of the resource type, such as UnitDept."),
+			"excluded_components": components("The components that are never part of the names of the resource type, such as Org."),
+		},
+	}
+}
+
+// ValidateConfig checks that the resource type is identified exactly once.
+func (r *ResourceTypeSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ResourceTypeSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ResourceTypeId.IsUnknown() || config.Resource.IsUnknown() {
+		return
+	}
+
+	if config.ResourceTypeId.IsNull() == config.Resource.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("resource_type_id"), "Invalid resource type",
+			"Exactly one of resource_type_id and resource must be set to identify the resource type.")
+	}
+}
+
+// Configure prepares the struct.
+func (r *ResourceTypeSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *apiclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// Create takes over the settings of an existing resource type, since resource types cannot be created.
+func (r *ResourceTypeSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_type_settings", "Create")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan ResourceTypeSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client not configured", "The provider client has not been configured.")
+		return
+	}
+
+	svc := apiclient.NewResourceTypeService(r.client)
+	var current *models.ResourceType
+	var err error
+	if knownInt64(plan.ResourceTypeId) {
+		current, err = r.getResourceType(ctx, plan.ResourceTypeId.ValueInt64())
+	} else {
+		current, err = svc.FindResourceTypeByResource(ctx, plan.Resource.ValueString())
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Could not find the resource type.", err)
+		return
+	}
+
+	model, ok := r.apply(ctx, plan, *current, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// Read handles reading the resource data.
+func (r *ResourceTypeSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_type_settings", "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state ResourceTypeSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceType, err := r.getResourceType(ctx, state.ID.ValueInt64())
+	if errors.Is(err, utils.ErrNotFound) {
+		tflog.Warn(ctx, fmt.Sprintf("The resource type %d no longer exists, removing it from the state", state.ID.ValueInt64()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the resource type.", err)
+		return
+	}
+
+	model, diags := newResourceTypeSettingsModel(ctx, *resourceType)
+	resp.Diagnostics.Append(diags...)
+	if strings.EqualFold(resourceType.Resource, state.Resource.ValueString()) {
+		model.Resource = state.Resource
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// Update handles updating the resource.
+func (r *ResourceTypeSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_type_settings", "Update")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var plan, state ResourceTypeSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.getResourceType(ctx, state.ID.ValueInt64())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Failed to read the resource type.", err)
+		return
+	}
+
+	model, ok := r.apply(ctx, plan, *current, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// Delete removes the resource type from the state. Resource types cannot be deleted, so the
+// Naming Tool keeps the applied settings.
+func (r *ResourceTypeSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_type_settings", "Delete")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var state ResourceTypeSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Resource type %s is no longer managed, its settings are left in the Naming Tool", state.Resource.ValueString()))
+}
+
+// ImportState imports the resource type by its ID or its resource.
+func (r *ResourceTypeSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_resource_type_settings", "ImportState")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	var resourceType *models.ResourceType
+	var err error
+	if id, parseErr := strconv.ParseInt(req.ID, 10, 64); parseErr == nil {
+		resourceType, err = r.getResourceType(ctx, id)
+	} else {
+		resourceType, err = apiclient.NewResourceTypeService(r.client).FindResourceTypeByResource(ctx, req.ID)
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, fmt.Sprintf("Could not find the resource type %q", req.ID), err)
+		return
+	}
+
+	model, diags := newResourceTypeSettingsModel(ctx, *resourceType)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// getResourceType returns the resource type with the ID, or an error matching utils.ErrNotFound
+// if there is none.
+func (r *ResourceTypeSettingsResource) getResourceType(ctx context.Context, id int64) (*models.ResourceType, error) {
+	resourceType, err := apiclient.NewResourceTypeService(r.client).GetResourceType(ctx, strconv.FormatInt(id, 10))
+	if err != nil {
+		return nil, err
+	}
+	if resourceType.Id == 0 {
+		return nil, fmt.Errorf("%w: resource type %d", utils.ErrNotFound, id)
+	}
+	return resourceType, nil
+}
+
+// apply sends the planned settings over the current settings of the resource type, and returns
+// the model of the resource type returned by the Naming Tool. Nothing is sent if no setting changes.
+func (r *ResourceTypeSettingsResource) apply(ctx context.Context, plan ResourceTypeSettingsResourceModel, current models.ResourceType, diags *diag.Diagnostics) (ResourceTypeSettingsResourceModel, bool) {
+	resourceType := current
+	if knownString(plan.ShortName) {
+		resourceType.ShortName = plan.ShortName.ValueString()
+	}
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		resourceType.Enabled = models.Bool(plan.Enabled.ValueBool())
+	}
+	if !plan.ApplyDelimiter.IsNull() && !plan.ApplyDelimiter.IsUnknown() {
+		resourceType.ApplyDelimiter = models.Bool(plan.ApplyDelimiter.ValueBool())
+	}
+	if components, ok := setElements(ctx, plan.OptionalComponents, diags); ok && !sameElements(components, current.OptionalComponents()) {
+		resourceType.SetOptionalComponents(components)
+	}
+	if components, ok := setElements(ctx, plan.ExcludedComponents, diags); ok && !sameElements(components, current.ExcludedComponents()) {
+		resourceType.SetExcludedComponents(components)
+	}
+	if diags.HasError() {
+		return ResourceTypeSettingsResourceModel{}, false
+	}
+
+	updated := &resourceType
+	if resourceType != current {
+		var err error
+		updated, err = apiclient.NewResourceTypeService(r.client).UpdateResourceType(ctx, resourceType)
+		if err != nil {
+			addAPIError(diags, fmt.Sprintf("Failed to update the resource type %q.", resourceType.Resource), err)
+			return ResourceTypeSettingsResourceModel{}, false
+		}
+		tflog.Info(ctx, fmt.Sprintf("Updated resource type %s", resourceType.Resource), map[string]interface{}{"id": resourceType.Id})
+	}
+
+	model, modelDiags := newResourceTypeSettingsModel(ctx, *updated)
+	diags.Append(modelDiags...)
+	if knownString(plan.Resource) {
+		model.Resource = plan.Resource
+	}
+	return model, !diags.HasError()
+}
+
+// setElements returns the strings of a known set, and false if the set is null or unknown.
+func setElements(ctx context.Context, set types.Set, diags *diag.Diagnostics) ([]string, bool) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, false
+	}
+	var elements []string
+	diags.Append(set.ElementsAs(ctx, &elements, false)...)
+	return elements, true
+}
+
+// sameElements reports whether a and b contain the same strings, in any order.
+func sameElements(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, item := range a {
+		counts[item]++
+	}
+	for _, item := range b {
+		if counts[item]--; counts[item] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// newResourceTypeSettingsModel returns the model of a resource type returned by the Naming Tool.
+func newResourceTypeSettingsModel(ctx context.Context, resourceType models.ResourceType) (ResourceTypeSettingsResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	optional, d := types.SetValueFrom(ctx, types.StringType, resourceType.OptionalComponents())
+	diags.Append(d...)
+	excluded, d := types.SetValueFrom(ctx, types.StringType, resourceType.ExcludedComponents())
+	diags.Append(d...)
+
+	return ResourceTypeSettingsResourceModel{
+		ID:                 types.Int64Value(resourceType.Id),
+		ResourceTypeId:     types.Int64Value(resourceType.Id),
+		Resource:           types.StringValue(resourceType.Resource),
+		ShortName:          types.StringValue(resourceType.ShortName),
+		Enabled:            types.BoolValue(bool(resourceType.Enabled)),
+		ApplyDelimiter:     types.BoolValue(bool(resourceType.ApplyDelimiter)),
+		OptionalComponents: optional,
+		ExcludedComponents: excluded,
+	}, diags
+}
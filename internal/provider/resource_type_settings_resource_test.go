@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceTypeSettingsResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestNamingTool(t)
+	server.Put(namingtooltest.ResourceTypes,
+		models.ResourceType{Id: 1, Resource: "KeyVault/vaults", ShortName: "kv", Optional: "UnitDept", Enabled: true, ApplyDelimiter: true},
+		models.ResourceType{Id: 2, Resource: "Storage/storageAccounts", ShortName: "st", Exclude: "Org", Enabled: true, ApplyDelimiter: true},
+	)
+	r, empty := newTestResource(t, &ResourceTypeSettingsResource{}, client)
+
+	excluded, _ := types.SetValueFrom(ctx, types.StringType, []string{"UnitDept", "Org"})
+	plan := newTestPlan(t, empty, ResourceTypeSettingsResourceModel{
+		ID:                 types.Int64Unknown(),
+		ResourceTypeId:     types.Int64Unknown(),
+		Resource:           types.StringValue("storage/storageaccounts"),
+		ShortName:          types.StringUnknown(),
+		Enabled:            types.BoolUnknown(),
+		ApplyDelimiter:     types.BoolValue(false),
+		OptionalComponents: types.SetUnknown(types.StringType),
+		ExcludedComponents: excluded,
+	})
+	createResp := resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	// Only the planned settings change, and the other resource types are sent unchanged
+	assert.Equal(t, 1, server.Writes(namingtooltest.ResourceTypes))
+	resourceTypes := namingtooltest.Records[models.ResourceType](server, namingtooltest.ResourceTypes)
+	assert.Equal(t, models.ResourceType{Id: 2, Resource: "Storage/storageAccounts", ShortName: "st", Exclude: "UnitDept,Org", Enabled: true}, resourceTypes[1])
+	assert.Equal(t, "UnitDept", resourceTypes[0].Optional)
+
+	var state ResourceTypeSettingsResourceModel
+	createResp.State.Get(ctx, &state)
+	assert.Equal(t, int64(2), state.ID.ValueInt64())
+	assert.Equal(t, "storage/storageaccounts", state.Resource.ValueString())
+	assert.Equal(t, 0, len(state.OptionalComponents.Elements()))
+
+	// The same components in another order are not sent again
+	excluded, _ = types.SetValueFrom(ctx, types.StringType, []string{"Org", "UnitDept"})
+	state.ExcludedComponents = excluded
+	plan.Set(ctx, state)
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	assert.Equal(t, 1, server.Writes(namingtooltest.ResourceTypes))
+
+	// Changes made in the Naming Tool show up on refresh
+	resourceTypes[1].Optional = "Instance"
+	server.Put(namingtooltest.ResourceTypes, resourceTypes[1])
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	readResp.State.Get(ctx, &state)
+	assert.Equal(t, "[\"Instance\"]", state.OptionalComponents.String())
+}
+
+func TestResourceTypeSettingsResourceImportState(t *testing.T) {
+	server, client := newTestNamingTool(t)
+	server.Put(namingtooltest.ResourceTypes,
+		models.ResourceType{Id: 1, Resource: "KeyVault/vaults", ShortName: "kv", Exclude: "UnitDept", Enabled: true},
+	)
+
+	for _, id := range []string{"1", "keyvault/vaults"} {
+		ctx := context.Background()
+		r, empty := newTestResource(t, &ResourceTypeSettingsResource{}, client)
+		resp := resource.ImportStateResponse{State: empty}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, &resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var state ResourceTypeSettingsResourceModel
+		resp.State.Get(ctx, &state)
+		assert.Equal(t, "KeyVault/vaults", state.Resource.ValueString())
+		assert.Equal(t, "[\"UnitDept\"]", state.ExcludedComponents.String())
+		assert.False(t, state.ApplyDelimiter.ValueBool())
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ServerInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &ServerInfoDataSource{}
+)
+
+func NewServerInfoDataSource() datasource.DataSource {
+	return &ServerInfoDataSource{}
+}
+
+// ServerInfoDataSource defines the data source exposing the Naming Tool version.
+type ServerInfoDataSource struct {
+	client *apiclient.APIClient
+}
+
+// ServerInfoDataSourceModel describes the data source data model.
+type ServerInfoDataSourceModel struct {
+	Version             types.String `tfsdk:"version"`
+	Supported           types.Bool   `tfsdk:"supported"`
+	Tested              types.Bool   `tfsdk:"tested"`
+	MinSupportedVersion types.String `tfsdk:"min_supported_version"`
+	MaxTestedVersion    types.String `tfsdk:"max_tested_version"`
+}
+
+// Metadata returns the data source type name.
+func (d *ServerInfoDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "aznamingtool_server_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *ServerInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Returns the version of the Naming Tool and whether the provider supports it.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The version of the Naming Tool, or null if it is unknown.",
+			},
+			"supported": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the provider supports the Naming Tool version, or null if it is unknown.",
+			},
+			"tested": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the provider was tested with the Naming Tool version, or null if it is unknown.",
+			},
+			"min_supported_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The oldest Naming Tool version the provider supports.",
+			},
+			"max_tested_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The newest Naming Tool version the provider was tested with.",
+			},
+		},
+	}
+}
+
+// Configure prepares the struct.
+func (d *ServerInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiclient.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *apiclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read handles reading the data source data.
+func (d *ServerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startOperation(ctx, "aznamingtool_server_info", "Read")
+	defer endOperation(ctx, span, &resp.Diagnostics)
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client not configured", "The provider client has not been configured.")
+		return
+	}
+
+	state := ServerInfoDataSourceModel{
+		Version:             types.StringNull(),
+		Supported:           types.BoolNull(),
+		Tested:              types.BoolNull(),
+		MinSupportedVersion: types.StringValue(apiclient.MinServerVersion.String()),
+		MaxTestedVersion:    types.StringValue(apiclient.MaxTestedServerVersion.String()),
+	}
+
+	version, err := d.client.DetectServerVersion(ctx)
+	switch {
+	case err == nil:
+		state.Version = types.StringValue(version.String())
+		state.Supported = types.BoolValue(version.Supported())
+		state.Tested = types.BoolValue(version.Tested())
+	case !errors.Is(err, utils.ErrNotFound):
+		addAPIError(&resp.Diagnostics, "Error reading Naming Tool version", err)
+		return
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
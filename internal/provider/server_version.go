@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// parseServerVersion parses the server_version attribute, adding an attribute error on failure.
+func parseServerVersion(value types.String, diags *diag.Diagnostics) apiclient.ServerVersion {
+	version, err := apiclient.ParseServerVersion(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("server_version"),
+			"Invalid Naming Tool version",
+			fmt.Sprintf("The value %q is not a version such as \"4.2.1\".", value.ValueString()),
+		)
+	}
+	return version
+}
+
+// checkServerVersion detects the Naming Tool version unless it was configured, and reports
+// versions that the provider does not support or was not tested with, or that it cannot detect.
+func checkServerVersion(ctx context.Context, client *apiclient.APIClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	version, err := client.DetectServerVersion(ctx)
+	if err != nil {
+		detail := fmt.Sprintf("The provider cannot detect the Naming Tool version: %s.", err)
+		if errors.Is(err, utils.ErrNotFound) {
+			detail = fmt.Sprintf("The Naming Tool does not report its version at %s. Check that base_url points to the Naming Tool.",
+				apiclient.EndpointGetServerVersion.Path)
+		}
+		diags.AddWarning(
+			"Unknown Naming Tool version",
+			fmt.Sprintf("%s The provider supports Naming Tool %s to %s. Set server_version to the version of your Naming Tool to remove this warning.",
+				detail, apiclient.MinServerVersion, apiclient.MaxTestedServerVersion),
+		)
+		return diags
+	}
+
+	tflog.Info(ctx, "Detected Naming Tool version", map[string]interface{}{"version": version.String()})
+
+	switch {
+	case !version.Supported():
+		diags.AddError(
+			"Unsupported Naming Tool version",
+			fmt.Sprintf("The Naming Tool runs version %s, but the provider requires version %s or later. Upgrade the Naming Tool or use an older provider release.",
+				version, apiclient.MinServerVersion),
+		)
+	case !version.Tested():
+		diags.AddWarning(
+			"Untested Naming Tool version",
+			fmt.Sprintf("The Naming Tool runs version %s, but the provider was only tested up to version %s. Check the plans for unexpected changes.",
+				version, apiclient.MaxTestedServerVersion),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckServerVersionWarnsAboutUnreportedVersion(t *testing.T) {
+	// The Naming Tool fake answers the version path with 404
+	_, client := newTestNamingTool(t)
+
+	diags := checkServerVersion(context.Background(), client)
+	assert.False(t, diags.HasError())
+	if assert.Len(t, diags.Warnings(), 1) {
+		assert.Equal(t, "Unknown Naming Tool version", diags.Warnings()[0].Summary())
+		assert.Contains(t, diags.Warnings()[0].Detail(), "Set server_version")
+	}
+	assert.True(t, client.Version().IsZero())
+}
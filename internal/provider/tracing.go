@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracerName is the instrumentation scope of the spans of Terraform operations.
+	tracerName = "github.com/rafaelherik/terraform-provider-aznamingtool/internal/provider"
+	// tracingServiceName is the service name of the exported spans unless OTEL_SERVICE_NAME is set.
+	tracingServiceName = "terraform-provider-aznamingtool"
+	// tracingFlushTimeout bounds the export of the spans at the end of every operation.
+	tracingFlushTimeout = 5 * time.Second
+)
+
+var (
+	tracingOnce sync.Once
+	// tracerProvider exports the spans of the provider process, or is nil when tracing is disabled.
+	tracerProvider *sdktrace.TracerProvider
+	tracingDiags   diag.Diagnostics
+)
+
+// setupTracing installs the tracer provider exporting the spans of the provider process
+// when the standard OTEL_* environment variables enable it. It runs once per process,
+// since Terraform starts a provider process for every plan or apply.
+func setupTracing(ctx context.Context, version string) diag.Diagnostics {
+	tracingOnce.Do(func() {
+		provider, err := newTracerProvider(ctx, version)
+		if err != nil {
+			tracingDiags.AddWarning(
+				"Tracing disabled",
+				fmt.Sprintf("The OpenTelemetry exporter cannot be created, so no spans are exported: %s", err),
+			)
+			return
+		}
+		if provider == nil {
+			return
+		}
+		tracerProvider = provider
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+		tflog.Info(ctx, "Exporting OpenTelemetry traces")
+	})
+	return tracingDiags
+}
+
+// newTracerProvider creates a tracer provider exporting spans with OTLP, configured by the
+// OTEL_* environment variables. Tracing is enabled when OTEL_TRACES_EXPORTER is "otlp" or an
+// OTLP endpoint is set, and disabled by OTEL_SDK_DISABLED or OTEL_TRACES_EXPORTER "none".
+//
+// Returns:
+//   - The tracer provider, or nil when tracing is disabled.
+//   - An error if the exporter or protocol is not supported or the exporter cannot be created.
+func newTracerProvider(ctx context.Context, version string) (*sdktrace.TracerProvider, error) {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return nil, nil
+	}
+	switch exporter := strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")); exporter {
+	case "none":
+		return nil, nil
+	case "":
+		if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+			return nil, nil
+		}
+	case "otlp":
+	default:
+		return nil, fmt.Errorf("the traces exporter %q is not supported, use \"otlp\"", exporter)
+	}
+
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch protocol {
+	case "", "http/protobuf":
+		exporter, err = otlptracehttp.New(ctx)
+	case "grpc":
+		exporter, err = otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("the OTLP protocol %q is not supported, use \"http/protobuf\" or \"grpc\"", protocol)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The environment comes last so that OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES win
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(tracingServiceName), semconv.ServiceVersion(version)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sampler is read from OTEL_TRACES_SAMPLER and the batching from OTEL_BSP_*
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)), nil
+}
+
+// startOperation starts the span of a Terraform operation, such as the Create of a resource.
+// Operations without a parent span continue the trace in the TRACEPARENT environment
+// variable, so that CI pipelines can link the provider spans to their own.
+//
+// Parameters:
+//   - ctx: The context of the operation.
+//   - typeName: The type name of the resource or data source, or the provider name.
+//   - operation: The name of the operation, such as "Read".
+//
+// Returns:
+//   - The context of the span, with the trace ID added to the log fields.
+//   - The span, to be ended with endOperation.
+func startOperation(ctx context.Context, typeName string, operation string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{
+			"traceparent": os.Getenv("TRACEPARENT"),
+			"tracestate":  os.Getenv("TRACESTATE"),
+		})
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, typeName+"."+operation, trace.WithAttributes(
+		attribute.String("terraform.type_name", typeName),
+		attribute.String("terraform.operation", operation),
+	))
+	if span.SpanContext().IsValid() {
+		ctx = tflog.SetField(ctx, "trace_id", span.SpanContext().TraceID().String())
+	}
+	return ctx, span
+}
+
+// endOperation ends the span of an operation, marking it as failed if diags has errors, and
+// exports the spans and writes the metrics files of the process. Both are flushed after every
+// operation because Terraform stops the provider process without notice.
+func endOperation(ctx context.Context, span trace.Span, diags *diag.Diagnostics) {
+	if errs := diags.Errors(); len(errs) > 0 {
+		for _, d := range errs {
+			span.RecordError(errors.New(d.Summary() + ": " + d.Detail()))
+		}
+		span.SetStatus(codes.Error, errs[0].Summary())
+	}
+	span.End()
+	flushMetrics(ctx)
+
+	if tracerProvider == nil {
+		return
+	}
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tracingFlushTimeout)
+	defer cancel()
+	if err := tracerProvider.ForceFlush(flushCtx); err != nil {
+		tflog.Warn(ctx, "Failed to export OpenTelemetry spans", map[string]interface{}{"error": err.Error()})
+	}
+}
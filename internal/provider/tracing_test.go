@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// otlpCollector is a local OTLP/HTTP collector keeping the spans it receives.
+type otlpCollector struct {
+	mu    sync.Mutex
+	spans []*tracepb.Span
+	attrs map[string]string // The string attributes of the resources
+}
+
+func (c *otlpCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, resourceSpans := range request.ResourceSpans {
+		for _, kv := range resourceSpans.Resource.Attributes {
+			c.attrs[kv.Key] = kv.Value.GetStringValue()
+		}
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			c.spans = append(c.spans, scopeSpans.Spans...)
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Write(nil)
+}
+
+func (c *otlpCollector) span(name string) *tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, span := range c.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+// useTracerProvider installs the tracer provider of the OTLP environment variables for the test.
+func useTracerProvider(t *testing.T) {
+	provider, err := newTracerProvider(context.Background(), "test")
+	if err != nil || provider == nil {
+		t.Fatalf("failed to create tracer provider: %v", err)
+	}
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	tracerProvider = provider
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		tracerProvider = nil
+		provider.Shutdown(context.Background())
+	})
+}
+
+func TestOperationSpansExportedToCollector(t *testing.T) {
+	collector := &otlpCollector{attrs: map[string]string{}}
+	collectorServer := httptest.NewServer(collector)
+	defer collectorServer.Close()
+
+	var traceparent string
+	namingTool := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`"4.2.1"`))
+	}))
+	defer namingTool.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collectorServer.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+	t.Setenv("OTEL_SERVICE_NAME", "pipeline-provider")
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	useTracerProvider(t)
+
+	client, err := apiclient.NewAPIClient(namingTool.URL, "key", "", namingTool.Client())
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	defer client.Close()
+
+	ctx, span := startOperation(context.Background(), "aznamingtool_server_info", "Read")
+	_, err = client.DetectServerVersion(ctx)
+	assert.NoError(t, err)
+	var diags diag.Diagnostics
+	diags.AddError("Error reading Naming Tool version", "boom")
+	endOperation(ctx, span, &diags)
+
+	operation := collector.span("aznamingtool_server_info.Read")
+	request := collector.span("GET /api/Admin/GetVersion")
+	if !assert.NotNil(t, operation) || !assert.NotNil(t, request) {
+		return
+	}
+
+	// The operation continues the trace of TRACEPARENT and the request is its child
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", hex.EncodeToString(operation.TraceId))
+	assert.Equal(t, "00f067aa0ba902b7", hex.EncodeToString(operation.ParentSpanId))
+	assert.Equal(t, operation.SpanId, request.ParentSpanId)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, operation.Status.Code)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+hex.EncodeToString(request.SpanId)+"-01", traceparent)
+	assert.Equal(t, "pipeline-provider", collector.attrs["service.name"])
+	assert.Equal(t, "test", collector.attrs["service.version"])
+}
+
+func TestNewTracerProviderFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		enabled bool
+		wantErr string
+	}{
+		{name: "disabled without endpoint"},
+		{name: "enabled by endpoint", env: map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, enabled: true},
+		{name: "enabled by traces endpoint", env: map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4318/v1/traces"}, enabled: true},
+		{name: "enabled by exporter", env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp"}, enabled: true},
+		{name: "grpc", env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp", "OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"}, enabled: true},
+		{name: "exporter none", env: map[string]string{"OTEL_TRACES_EXPORTER": "none", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}},
+		{name: "sdk disabled", env: map[string]string{"OTEL_SDK_DISABLED": "true", "OTEL_TRACES_EXPORTER": "otlp"}},
+		{name: "unsupported exporter", env: map[string]string{"OTEL_TRACES_EXPORTER": "zipkin"}, wantErr: `"zipkin" is not supported`},
+		{name: "unsupported protocol", env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/json"}, wantErr: `"http/json" is not supported`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			provider, err := newTracerProvider(context.Background(), "test")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.enabled, provider != nil)
+			if provider != nil {
+				provider.Shutdown(context.Background())
+			}
+		})
+	}
+}
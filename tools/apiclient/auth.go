@@ -0,0 +1,179 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a cached access token is refreshed.
+const tokenExpiryDelta = 30 * time.Second
+
+// Authenticator adds credentials to requests sent to the Naming Tool.
+type Authenticator interface {
+	// Authenticate sets the credentials on req. It is called once per attempt.
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// APIKeyAuthenticator authenticates requests with the Naming Tool API key.
+type APIKeyAuthenticator struct {
+	APIKey string // The API key sent in the APIKey header.
+}
+
+// Authenticate sets the APIKey header.
+func (a *APIKeyAuthenticator) Authenticate(_ context.Context, req *http.Request) error {
+	req.Header.Set("APIKey", a.APIKey)
+	return nil
+}
+
+// AdminPasswordAuthenticator authenticates requests that need the Naming Tool admin password.
+type AdminPasswordAuthenticator struct {
+	Password string // The admin password sent in the AdminPassword header.
+}
+
+// Authenticate sets the AdminPassword header on requests to AuthAdmin endpoints, so the
+// password is not sent along with every request. Requests that were not sent through
+// BaseService carry no endpoint and always get the header.
+func (a *AdminPasswordAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	if endpoint, ok := EndpointFromContext(ctx); ok && endpoint.Auth != AuthAdmin {
+		return nil
+	}
+	req.Header.Set("AdminPassword", a.Password)
+	return nil
+}
+
+// BearerTokenAuthenticator authenticates requests with a static OAuth2 bearer token.
+type BearerTokenAuthenticator struct {
+	Token string // The access token sent in the Authorization header.
+}
+
+// Authenticate sets the Authorization header.
+func (a *BearerTokenAuthenticator) Authenticate(_ context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// ClientCredentialsAuthenticator authenticates requests with a bearer token obtained through
+// the OAuth2 client credentials grant, such as an Entra ID token for App Service Authentication.
+// The token is cached and refreshed shortly before it expires.
+type ClientCredentialsAuthenticator struct {
+	TokenURL     string       // The token endpoint, e.g. https://login.microsoftonline.com/{tenant}/oauth2/v2.0/token.
+	ClientID     string       // The client ID of the application requesting the token.
+	ClientSecret string       // The client secret of the application requesting the token.
+	Scopes       []string     // The scopes requested, e.g. api://{naming-tool-app}/.default.
+	HttpClient   *http.Client // The HTTP client used to reach the token endpoint. Defaults to http.DefaultClient.
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// tokenResponse is the successful response of an OAuth2 token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// tokenErrorResponse is the error response of an OAuth2 token endpoint.
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Authenticate sets the Authorization header, requesting a new token if the cached one expired.
+func (a *ClientCredentialsAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns a valid access token, requesting a new one if the cached one expired.
+//
+// Parameters:
+//   - ctx: The context used to cancel the token request.
+//
+// Returns:
+//   - The access token.
+//   - An error if the token endpoint cannot be reached or rejects the credentials.
+func (a *ClientCredentialsAuthenticator) Token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && time.Now().Before(a.expiry.Add(-tokenExpiryDelta)) {
+		return a.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {a.ClientID},
+		"client_secret": {a.ClientSecret},
+	}
+	if len(a.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := a.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read access token response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var tokenErr tokenErrorResponse
+		if json.Unmarshal(body, &tokenErr) == nil && tokenErr.Error != "" {
+			return "", fmt.Errorf("token endpoint returned %d: %s: %s", resp.StatusCode, tokenErr.Error, tokenErr.ErrorDescription)
+		}
+		return "", fmt.Errorf("token endpoint returned %d", resp.StatusCode)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed to decode access token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint returned no access token")
+	}
+
+	a.token = token.AccessToken
+	a.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return a.token, nil
+}
+
+// MultiAuthenticator applies several authenticators to every request, in order.
+type MultiAuthenticator []Authenticator
+
+// Authenticate applies every authenticator, stopping at the first error.
+func (m MultiAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	for _, authenticator := range m {
+		if err := authenticator.Authenticate(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTokenServer(t *testing.T, issued *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		if r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_client", "error_description": "Invalid client secret."}`))
+			return
+		}
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "app", r.PostForm.Get("client_id"))
+		assert.Equal(t, "api://naming-tool/.default", r.PostForm.Get("scope"))
+
+		atomic.AddInt32(issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "token-123", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+}
+
+func TestClientCredentialsAuthenticator(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(t, &issued)
+	defer tokenServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-123", r.Header.Get("Authorization"))
+		assert.Equal(t, "123456", r.Header.Get("APIKey"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	authenticator := &ClientCredentialsAuthenticator{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "app",
+		ClientSecret: "secret",
+		Scopes:       []string{"api://naming-tool/.default"},
+	}
+	client := newTestClient(t, server.URL, server.Client(),
+		WithAuthenticator(MultiAuthenticator{NewDefaultAuthenticator("123456", ""), authenticator}))
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := client.DoRequest(context.Background(), req)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&issued))
+
+	// An expiring token is refreshed before it is used again.
+	authenticator.expiry = time.Now().Add(tokenExpiryDelta / 2)
+	_, err := authenticator.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+}
+
+func TestClientCredentialsAuthenticatorRejected(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(t, &issued)
+	defer tokenServer.Close()
+
+	authenticator := &ClientCredentialsAuthenticator{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "app",
+		ClientSecret: "wrong",
+	}
+
+	_, err := authenticator.Token(context.Background())
+
+	assert.ErrorContains(t, err, "invalid_client: Invalid client secret.")
+}
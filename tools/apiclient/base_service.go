@@ -2,10 +2,15 @@ package apiclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
 )
 
 type BaseService struct {
@@ -24,171 +29,302 @@ func NewBaseService(client *APIClient) *BaseService {
 }
 
 // DoGet performs a GET request to the specified endpoint with URL interpolation
-// and decodes the response into the provided response object.
+// and decodes the response into the provided response object. Responses of Cacheable
+// endpoints are served from the client's ResponseCache when it is enabled.
 //
 // Parameters:
-//   - endpointKey: The key to the API endpoint in the client's endpoint map.
-//   - uriData: A map containing data to be interpolated into the endpoint URL.
+//   - ctx: The context used to cancel the request.
+//   - endpoint: The API endpoint to call. Its method must be GET.
+//   - uriData: A map containing the values of the endpoint path parameters.
 //   - response: A pointer to a variable where the response should be decoded.
 //
 // Returns:
 //   - error: An error if any of the following occurs:
-//   - The endpoint is not found in the client's endpoint map.
+//   - The endpoint is not a GET endpoint or uriData does not match its parameters.
 //   - The request creation fails.
 //   - The request execution fails.
 //   - The response body decoding fails.
-//   - The response status code is 400 or greater.
-func (s *BaseService) DoGet(endpointKey string, uriData map[string]string, response interface{}) error {
-	if err := s.validateClientAndEndpoint(endpointKey); err != nil {
+//   - The response status code is 400 or greater, reported as an *APIError.
+func (s *BaseService) DoGet(ctx context.Context, endpoint Endpoint, uriData map[string]string, response interface{}) error {
+	endpointURL, err := s.endpointURL(endpoint, http.MethodGet, uriData)
+	if err != nil {
 		return err
 	}
 
-	endpoint := s.interpolateURL(endpointKey, uriData)
-
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		return err
+	ctx = withEndpoint(ctx, endpoint)
+	if endpoint.Cacheable && s.client.Cache != nil {
+		body, err := s.client.Cache.Get(ctx, endpoint, endpointURL, func(ctx context.Context, etag string) (*http.Response, error) {
+			var header http.Header
+			if etag != "" {
+				header = http.Header{"If-None-Match": []string{etag}}
+			}
+			return s.send(ctx, http.MethodGet, endpointURL, nil, header)
+		})
+		if err != nil {
+			return err
+		}
+		return s.decode(bytes.NewReader(body), response)
 	}
 
-	resp, err := s.client.DoRequest(req)
+	resp, err := s.send(ctx, http.MethodGet, endpointURL, nil, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("received error status code: %d", resp.StatusCode)
+		return newAPIError(endpoint.Name, resp)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-		return err
-	}
-
-	return nil
+	return s.decode(resp.Body, response)
 }
 
 // DoPost performs a POST request to the specified endpoint with a JSON-encoded body
 // and decodes the response into the provided response object.
 //
 // Parameters:
-//   - endpointKey: A string representing the key to the API endpoint in the client's endpoint map.
+//   - ctx: The context used to cancel the request.
+//   - endpoint: The API endpoint to call. Its method must be POST and it cannot have path parameters.
 //   - requestData: An object that will be serialized into a JSON object to be included in the POST request body.
-//   - response: A pointer to a variable where the decoded response should be stored.
+//   - response: A pointer to a variable where the decoded response should be stored, or nil
+//     to discard the response body.
 //
 // Returns:
 //   - error: An error if any of the following occurs:
-//   - The endpoint is not found in the client's endpoint map.
+//   - The endpoint is not a POST endpoint or requires path parameters.
 //   - The request creation fails.
 //   - The request execution fails.
 //   - The response body decoding fails.
-//   - The response status code is 400 or greater.
-func (s *BaseService) DoPost(endpointKey string, requestData interface{}, response interface{}) error {
-	if err := s.validateClientAndEndpoint(endpointKey); err != nil {
+//   - The response status code is 400 or greater, reported as an *APIError.
+func (s *BaseService) DoPost(ctx context.Context, endpoint Endpoint, requestData interface{}, response interface{}) error {
+	endpointURL, err := s.endpointURL(endpoint, http.MethodPost, nil)
+	if err != nil {
 		return err
 	}
 
-	endpoint := s.client.ApiEndpoints[endpointKey]
-
-	var req *http.Request
-	var err error
-
+	var jsonData []byte
 	if requestData != nil {
-		jsonData, err := json.Marshal(requestData)
-		if err != nil {
-			return err
-		}
-		req, err = http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonData))
-		req.Header.Set("Content-Type", "application/json")
-		if err != nil {
-			return err
-		}
-	} else {
-		req, err = http.NewRequest("POST", endpoint, nil)
+		jsonData, err = json.Marshal(requestData)
 		if err != nil {
 			return err
 		}
 	}
 
-	resp, err := s.client.DoRequest(req)
+	resp, err := s.send(withEndpoint(ctx, endpoint), http.MethodPost, endpointURL, jsonData, nil)
+	s.invalidateCache(endpoint)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("received error status code: %d", resp.StatusCode)
+		return newAPIError(endpoint.Name, resp)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-		return err
-	}
-
-	return nil
+	return s.decode(resp.Body, response)
 }
 
-// DoDelete performs a DELETE request to the specified endpoint with URL interpolation
-// and decodes the response into the provided response object.
+// DoDelete performs a DELETE request to the specified endpoint with URL interpolation.
 //
 // Parameters:
-//   - endpointKey: The key to the API endpoint in the client's endpoint map.
-//   - uriData: A map containing data to be interpolated into the endpoint URL.
+//   - ctx: The context used to cancel the request.
+//   - endpoint: The API endpoint to call. Its method must be DELETE.
+//   - uriData: A map containing the values of the endpoint path parameters.
 //
 // Returns:
 //   - error: An error if any of the following occurs:
-//   - The endpoint is not found in the client's endpoint map.
+//   - The endpoint is not a DELETE endpoint or uriData does not match its parameters.
 //   - The request creation fails.
 //   - The request execution fails.
-func (s *BaseService) DoDelete(endpointKey string, uriData map[string]string) error {
-	if err := s.validateClientAndEndpoint(endpointKey); err != nil {
-		return err
-	}
-
-	endpoint := s.interpolateURL(endpointKey, uriData)
-
-	req, err := http.NewRequest("DELETE", endpoint, nil)
+//   - The response status code is 400 or greater, reported as an *APIError.
+func (s *BaseService) DoDelete(ctx context.Context, endpoint Endpoint, uriData map[string]string) error {
+	endpointURL, err := s.endpointURL(endpoint, http.MethodDelete, uriData)
 	if err != nil {
 		return err
 	}
 
-	resp, err := s.client.DoRequest(req)
+	resp, err := s.send(withEndpoint(ctx, endpoint), http.MethodDelete, endpointURL, nil, nil)
+	s.invalidateCache(endpoint)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("received error status code: %d", resp.StatusCode)
+		return newAPIError(endpoint.Name, resp)
 	}
 
 	return nil
 }
 
-// validateClientAndEndpoint checks if the client and endpoint are properly initialized
-func (s *BaseService) validateClientAndEndpoint(endpointKey string) error {
-	if s == nil {
-		return fmt.Errorf("BaseService is nil")
+// send executes the request through the client queue, retrying it according to the
+// client's RetryPolicy, except for a 404 from an Optional endpoint. Each attempt fails
+// over to the next healthy instance when an instance cannot answer.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request and any wait between attempts.
+//   - method: The HTTP method of the request.
+//   - endpoint: The interpolated URL of the request.
+//   - body: The JSON body of the request, or nil for requests without a body.
+//   - header: Additional headers of the request, or nil.
+//
+// Returns:
+//   - The response of the last attempt. The caller must close its body.
+//   - An error if the last attempt failed or ctx was cancelled while waiting.
+func (s *BaseService) send(ctx context.Context, method string, endpoint string, body []byte, header http.Header) (*http.Response, error) {
+	policy := s.client.RetryPolicy
+	operation, _ := EndpointFromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := s.sendToInstances(ctx, method, endpoint, body, header, attempt)
+		if ctx.Err() != nil || attempt >= policy.MaxAttempts || !policy.shouldRetry(method, resp, err) || operation.unserved(resp) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			tflog.Warn(ctx, "Retrying Naming Tool request", map[string]interface{}{
+				"method": method, "url": endpoint, "status": resp.StatusCode, "attempt": attempt, "wait": wait.String(),
+			})
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			tflog.Warn(ctx, "Retrying Naming Tool request", map[string]interface{}{
+				"method": method, "url": endpoint, "error": err.Error(), "attempt": attempt, "wait": wait.String(),
+			})
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
 	}
-	if endpointKey == "" {
-		return fmt.Errorf("url is empty")
+}
+
+// sendToInstances sends one attempt of the request to the instances in health order, until
+// an instance answers or the request cannot fail over. The body is rebuilt for every
+// request, and every request is traced as a client span whose context is sent in the
+// traceparent header.
+func (s *BaseService) sendToInstances(ctx context.Context, method string, endpoint string, body []byte, header http.Header, attempt int) (*http.Response, error) {
+	instances := s.client.instanceOrder(ctx)
+	for i, baseURL := range instances {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, s.client.rebaseURL(endpoint, baseURL), reader)
+		if err != nil {
+			return nil, err
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		spanCtx, span := s.client.startRequestSpan(ctx, req, attempt)
+		resp, err := s.client.DoRequest(spanCtx, req)
+		endRequestSpan(span, resp, err)
+		if i == len(instances)-1 || ctx.Err() != nil || !shouldFailover(method, resp, err) {
+			if err == nil {
+				recordInstance(ctx, baseURL)
+			}
+			return resp, err
+		}
+
+		s.client.markUnhealthy(ctx, baseURL, failoverCause(resp, err))
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
 	}
-	if s.client == nil {
-		return fmt.Errorf("client is nil")
+	return nil, fmt.Errorf("no Naming Tool instance configured")
+}
+
+// decode decodes the JSON response body into response. With strict decoding enabled,
+// properties that response has no field for are reported as errors. A nil response
+// discards the body, and a *bytes.Buffer response receives it undecoded. Decoding errors
+// match utils.ErrInvalidResponse.
+func (s *BaseService) decode(body io.Reader, response interface{}) error {
+	if response == nil {
+		return nil
 	}
-	if s.client.ApiEndpoints == nil {
-		return fmt.Errorf("ApiEndpoints is nil")
+	if buffer, ok := response.(*bytes.Buffer); ok {
+		_, err := buffer.ReadFrom(body)
+		return err
+	}
+	decoder := json.NewDecoder(body)
+	if s.client.StrictDecoding {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(response); err != nil {
+		return fmt.Errorf("%w: failed to decode Naming Tool response: %w", utils.ErrInvalidResponse, err)
 	}
 	return nil
 }
 
-// interpolateURL performs string interpolation on the endpoint URL
-func (s *BaseService) interpolateURL(endpointKey string, uriData map[string]string) string {
-	endpoint := s.client.ApiEndpoints[endpointKey]
+// postWrite posts request to a write endpoint of the Naming Tool and returns the written
+// record. Some writes answer with a text confirmation, such as "Resource Types added!",
+// instead of the record. Such a body returns the zero value of T, without ID, and callers
+// look the record up. JSON bodies are decoded as T, and decoding errors are returned.
+func postWrite[T any](ctx context.Context, s *BaseService, endpoint Endpoint, request interface{}) (*T, error) {
+	var body bytes.Buffer
+	if err := s.DoPost(ctx, endpoint, request, &body); err != nil {
+		return nil, err
+	}
+
+	var response T
+	if isConfirmation(body.Bytes()) {
+		tflog.Debug(ctx, "The Naming Tool did not answer the write with the record", map[string]interface{}{"endpoint": endpoint.Name, "body": body.String()})
+		return &response, nil
+	}
+	if err := s.decode(&body, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// isConfirmation reports whether body is a text confirmation rather than a JSON record: an
+// empty body, a JSON string, or plain text that is not JSON. Truncated or corrupt objects
+// and arrays are not confirmations.
+func isConfirmation(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return true
+	}
+	switch trimmed[0] {
+	case '{', '[':
+		return false
+	case '"':
+		return json.Valid(trimmed)
+	}
+	return !json.Valid(trimmed)
+}
 
-	// Perform string interpolation with uriData
-	for key, value := range uriData {
-		placeholder := fmt.Sprintf("{%s}", key)
-		endpoint = strings.Replace(endpoint, placeholder, value, -1)
+// invalidateCache removes the cached responses of the collection written by endpoint. It is
+// called whatever the outcome of the write, since a failed request may still have been applied.
+func (s *BaseService) invalidateCache(endpoint Endpoint) {
+	if s.client.Cache != nil {
+		s.client.Cache.Invalidate(endpoint)
 	}
+}
 
-	return endpoint
+// endpointURL checks that the service is initialized and that endpoint is called with
+// method, then builds the URL of the endpoint variant of the Naming Tool version below
+// the client's base URL.
+func (s *BaseService) endpointURL(endpoint Endpoint, method string, uriData map[string]string) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("BaseService is nil")
+	}
+	if s.client == nil {
+		return "", utils.ErrorClientNotInitialized
+	}
+	if endpoint.Method != method {
+		return "", fmt.Errorf("%w: %s is a %s endpoint, not %s", utils.ErrInvalidEndpoint, endpoint.Name, endpoint.Method, method)
+	}
+	return endpoint.forVersion(s.client.Version()).URL(s.client.BaseURL, uriData)
 }
@@ -1,12 +1,14 @@
 package apiclient
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,11 +22,11 @@ func TestDoGet(t *testing.T) {
 	}))
 	defer server.Close()
 	httpClient := server.Client()
-	client := NewAPIClient(server.URL, "123456", "123456", httpClient)
+	client := newTestClient(t, server.URL, httpClient)
 	service := NewBaseService(client)
 
 	var actualResponse []models.ResourceType
-	err := service.DoGet("RequestName", nil, &actualResponse)
+	err := service.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &actualResponse)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResponse, actualResponse)
@@ -45,11 +47,11 @@ func TestDoPost(t *testing.T) {
 	defer server.Close()
 
 	httpClient := server.Client()
-	client := NewAPIClient(server.URL, "123456", "123456", httpClient)
+	client := newTestClient(t, server.URL, httpClient)
 	service := NewBaseService(client)
 
 	var actualResponse models.ResourceUnit
-	err := service.DoPost("CreateOrUpdateResourceUnit", requestData, &actualResponse)
+	err := service.DoPost(context.Background(), EndpointCreateOrUpdateResourceUnit, requestData, &actualResponse)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResponse, actualResponse)
@@ -67,11 +69,62 @@ func TestDoDelete(t *testing.T) {
 	defer server.Close()
 
 	httpClient := server.Client()
-	client := NewAPIClient(server.URL, "123456", "123456", httpClient)
+	client := newTestClient(t, server.URL, httpClient)
 	service := NewBaseService(client)
 
-	result := service.DoDelete("DeleteResourceUnit", map[string]string{"id": "1"})
+	result := service.DoDelete(context.Background(), EndpointDeleteResourceUnit, map[string]string{"id": "1"})
 
 	assert.NoError(t, result)
 
 }
+
+func TestDoGetStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": 1, "resource": "Resource1", "newProperty": "value"}]`))
+	}))
+	defer server.Close()
+
+	var response []models.ResourceType
+	lenient := NewBaseService(newTestClient(t, server.URL, server.Client()))
+	assert.NoError(t, lenient.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &response))
+
+	strict := NewBaseService(newTestClient(t, server.URL, server.Client(), WithStrictDecoding(true)))
+	err := strict.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &response)
+	assert.ErrorContains(t, err, "newProperty")
+}
+
+func TestPostWrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		strict   bool
+		expected int64
+		invalid  bool
+	}{
+		{name: "record", body: `{"id": 7, "name": "Resource1"}`, expected: 7},
+		{name: "plain text confirmation", body: "Record added!"},
+		{name: "JSON string confirmation", body: `"Record added!"`},
+		{name: "empty body", body: ""},
+		{name: "truncated record", body: `{"id": 7, "na`, invalid: true},
+		{name: "unknown property in strict mode", body: `{"id": 7, "colour": "red"}`, strict: true, invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client := newTestClient(t, server.URL, server.Client(), WithStrictDecoding(tt.strict))
+
+			unit, err := postWrite[models.ResourceUnit](context.Background(), NewBaseService(client), EndpointCreateOrUpdateResourceUnit, models.ResourceUnit{})
+			if tt.invalid {
+				assert.ErrorIs(t, err, utils.ErrInvalidResponse)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, unit.Id)
+			}
+		})
+	}
+}
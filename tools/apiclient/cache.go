@@ -0,0 +1,187 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultCacheTTL is how long cached reference data is served without asking the Naming Tool.
+const DefaultCacheTTL = 5 * time.Minute
+
+// ResponseCache is a read-through cache for the responses of Cacheable endpoints, shared by
+// every service of a client. Expired entries are revalidated with If-None-Match when the
+// Naming Tool returned an ETag, and concurrent requests for the same URL share one fetch.
+type ResponseCache struct {
+	TTL time.Duration // How long an entry is served without revalidation.
+
+	mu         sync.Mutex
+	entries    map[string]*cacheEntry // Cached responses by URL
+	calls      map[string]*cacheCall  // Fetches in progress by URL
+	generation uint64                 // Incremented by every invalidation
+}
+
+type cacheEntry struct {
+	collection string
+	body       []byte
+	etag       string
+	expires    time.Time
+}
+
+type cacheCall struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// cacheFetch sends a GET request, adding the If-None-Match header when etag is set.
+type cacheFetch func(ctx context.Context, etag string) (*http.Response, error)
+
+// NewResponseCache creates an empty cache whose entries are fresh for ttl.
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		TTL:     ttl,
+		entries: map[string]*cacheEntry{},
+		calls:   map[string]*cacheCall{},
+	}
+}
+
+// WithCache caches the responses of reference data endpoints for ttl. A ttl of zero or
+// less disables the cache.
+func WithCache(ttl time.Duration) ClientOption {
+	return func(c *APIClient) {
+		if ttl <= 0 {
+			c.Cache = nil
+			return
+		}
+		c.Cache = NewResponseCache(ttl)
+	}
+}
+
+// Get returns the body cached for url, fetching it if the entry is missing or expired.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request or the wait for a concurrent fetch.
+//   - endpoint: The endpoint the URL belongs to, used to invalidate the entry on writes.
+//   - url: The URL of the request, used as cache key.
+//   - fetch: Sends the request when the entry has to be fetched or revalidated.
+//
+// Returns:
+//   - The response body.
+//   - An error if the fetch failed. Failed responses are not cached.
+func (c *ResponseCache) Get(ctx context.Context, endpoint Endpoint, url string, fetch cacheFetch) ([]byte, error) {
+	for {
+		c.mu.Lock()
+		entry := c.entries[url]
+		if entry != nil && time.Now().Before(entry.expires) {
+			c.mu.Unlock()
+			tflog.Debug(ctx, "Serving Naming Tool response from cache", map[string]interface{}{"url": url})
+			return entry.body, nil
+		}
+
+		if call, ok := c.calls[url]; ok {
+			c.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			// The fetch was cancelled by the caller that started it, so try again with ours
+			if isContextError(call.err) && ctx.Err() == nil {
+				continue
+			}
+			return call.body, call.err
+		}
+
+		call := &cacheCall{done: make(chan struct{})}
+		c.calls[url] = call
+		c.mu.Unlock()
+
+		call.body, call.err = c.fetch(ctx, endpoint, url, entry, fetch)
+
+		c.mu.Lock()
+		delete(c.calls, url)
+		c.mu.Unlock()
+		close(call.done)
+		return call.body, call.err
+	}
+}
+
+// fetch sends the request for url and stores a successful response, revalidating stale
+// when it has an ETag.
+func (c *ResponseCache) fetch(ctx context.Context, endpoint Endpoint, url string, stale *cacheEntry, fetch cacheFetch) ([]byte, error) {
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+
+	etag := ""
+	if stale != nil {
+		etag = stale.etag
+	}
+
+	resp, err := fetch(ctx, etag)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && stale != nil {
+		tflog.Debug(ctx, "Revalidated cached Naming Tool response", map[string]interface{}{"url": url})
+		c.store(url, &cacheEntry{collection: stale.collection, body: stale.body, etag: stale.etag}, generation)
+		return stale.body, nil
+	}
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(endpoint.Name, resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.store(url, &cacheEntry{collection: endpoint.collection(), body: body, etag: resp.Header.Get("ETag")}, generation)
+	return body, nil
+}
+
+// store saves entry for url, setting its expiry from the cache TTL. The entry is dropped
+// if the cache was invalidated since generation, as it may predate a write.
+func (c *ResponseCache) store(url string, entry *cacheEntry, generation uint64) {
+	entry.expires = time.Now().Add(c.TTL)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == generation {
+		c.entries[url] = entry
+	}
+}
+
+// Invalidate removes the cached responses of every endpoint in the collection of endpoint,
+// such as all /api/ResourceLocations responses after a location was written.
+func (c *ResponseCache) Invalidate(endpoint Endpoint) {
+	collection := endpoint.collection()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for url, entry := range c.entries {
+		if entry.collection == collection {
+			delete(c.entries, url)
+		}
+	}
+}
+
+// Clear removes every cached response.
+func (c *ResponseCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = map[string]*cacheEntry{}
+}
+
+// isContextError reports whether err was caused by a cancelled or expired context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
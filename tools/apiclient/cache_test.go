@@ -0,0 +1,133 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheServesRepeatedReads(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"Id": 1, "Resource": "Resource1"}]`))
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newTestClient(t, server.URL, server.Client(), WithCache(time.Minute)))
+
+	for i := 0; i < 3; i++ {
+		var response []models.ResourceType
+		assert.NoError(t, service.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &response))
+		assert.Equal(t, "Resource1", response[0].Resource)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	var calls, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"Id": 1, "Name": "westeurope"}]`))
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newTestClient(t, server.URL, server.Client(), WithCache(time.Nanosecond)))
+
+	for i := 0; i < 2; i++ {
+		var response []models.ResourceLocation
+		assert.NoError(t, service.DoGet(context.Background(), EndpointGetAllResourceLocations, nil, &response))
+		assert.Equal(t, "westeurope", response[0].Name)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+}
+
+func TestCacheDeduplicatesConcurrentReads(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newTestClient(t, server.URL, server.Client(), WithCache(time.Minute)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var response []models.ResourceDelimiter
+			assert.NoError(t, service.DoGet(context.Background(), EndpointGetAllResourceDelimiters, nil, &response))
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestCacheInvalidatedByWrites(t *testing.T) {
+	var reads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&reads, 1)
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newTestClient(t, server.URL, server.Client(), WithCache(time.Minute)))
+	ctx := context.Background()
+
+	var locations []models.ResourceLocation
+	var types []models.ResourceType
+	var location models.ResourceLocation
+	assert.NoError(t, service.DoGet(ctx, EndpointGetAllResourceLocations, nil, &locations))
+	assert.NoError(t, service.DoGet(ctx, EndpointGetAllResourceTypes, nil, &types))
+	assert.NoError(t, service.DoPost(ctx, EndpointCreateOrUpdateResourceLocation, models.ResourceLocation{}, &location))
+	assert.NoError(t, service.DoGet(ctx, EndpointGetAllResourceLocations, nil, &locations))
+	assert.NoError(t, service.DoGet(ctx, EndpointGetAllResourceTypes, nil, &types))
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&reads))
+}
+
+func TestCacheDoesNotStoreErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newTestClient(t, server.URL, server.Client(), WithCache(time.Minute)))
+
+	for i := 0; i < 2; i++ {
+		var response models.ResourceType
+		err := service.DoGet(context.Background(), EndpointGetResourceType, map[string]string{"id": "1"}, &response)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -1,23 +1,153 @@
 package apiclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultParallelism is the number of read requests executed concurrently when none is configured.
+	DefaultParallelism = 4
+	// DefaultQueueDepth is the number of requests each queue holds before callers have to wait.
+	DefaultQueueDepth = 100
+	// DefaultQueueTimeout is how long a caller waits for a free queue slot before failing.
+	DefaultQueueTimeout = 5 * time.Minute
 )
 
 // APIClient provides a client for making API requests to the resource naming service.
 type APIClient struct {
-	BaseURL       string            // The base URL of the API.
-	APIKey        string            // The API key for authenticating requests.
-	AdminPassword string            // The admin password for authenticating requests.
-	ApiEndpoints  map[string]string // A map of endpoint keys to endpoint URLs.
-	HttpClient    *http.Client      // The HTTP client used to make requests.
-	requestQueue  chan requestEntry // A channel to queue requests
+	BaseURL             string               // The base URL of the API.
+	APIKey              string               // The API key for authenticating requests.
+	AdminPassword       string               // The admin password for authenticating requests.
+	HttpClient          *http.Client         // The HTTP client used to make requests.
+	RetryPolicy         RetryPolicy          // The policy used to retry failed requests.
+	Parallelism         int                  // The number of read requests executed concurrently.
+	QueueDepth          int                  // The number of requests each queue holds before callers have to wait.
+	QueueTimeout        time.Duration        // How long a caller waits for a free queue slot. Zero waits until the context is done.
+	RateLimiter         *RateLimiter         // Limits the rate of requests sent by all queues. Nil disables rate limiting.
+	Authenticator       Authenticator        // Adds credentials to every request. Defaults to the API key and admin password headers.
+	LogBodies           bool                 // Whether request and response bodies are written to the api log subsystem.
+	Cache               *ResponseCache       // Caches the responses of Cacheable endpoints. Nil disables caching.
+	StrictDecoding      bool                 // Whether response properties missing from the models are reported as errors.
+	Metrics             *Metrics             // Counts requests, errors, latency and queue waits per endpoint. Nil disables collection.
+	MetricsFile         string               // The file the metrics are written to by FlushMetrics and Close. Empty disables writing.
+	ServerVersion       ServerVersion        // The Naming Tool version, used to choose endpoint variants. Zero if unknown.
+	TracerProvider      trace.TracerProvider // Creates the spans of the requests. Nil uses the global OpenTelemetry provider.
+	FailoverURLs        []string             // Standby instances sharing the storage of BaseURL, tried in order when it fails.
+	HealthCheckInterval time.Duration        // How long an instance that failed is skipped before it is probed again.
+	CreatedBy           string               // Recorded as the creator of the names requested by the client.
+	AdoptionWindow      time.Duration        // How old a generated name may be to be adopted by RequestNameOnce. Zero disables adoption.
+	instances           []*instance          // BaseURL and FailoverURLs with their health
+	healthMu            sync.Mutex           // Guards the health of the instances
+	resourceTypesMu     sync.Mutex           // Serializes the read-modify-write of the resource types configuration
+	componentsMu        sync.Mutex           // Serializes the sort order check and write of UpdateResourceComponent
+	readQueue           chan requestEntry    // A channel to queue requests that are safe to run in parallel
+	serialQueue         chan requestEntry    // A channel to queue requests that must run one at a time
+	mu                  sync.RWMutex         // Guards closed, ServerVersion, versionUnreported and sending to the queues
+	closed              bool                 // Whether Close was called
+	versionUnreported   bool                 // Whether the Naming Tool answered that it does not report its version
+	workers             sync.WaitGroup       // Tracks the queue workers until Close returns
+	metricsChanged      atomic.Bool          // Whether requests completed since the metrics were last written
+}
+
+// ClientOption configures optional settings of an APIClient.
+type ClientOption func(*APIClient)
+
+// WithParallelism sets the number of read requests executed concurrently.
+func WithParallelism(parallelism int) ClientOption {
+	return func(c *APIClient) {
+		c.Parallelism = parallelism
+	}
+}
+
+// WithQueueDepth sets the number of requests each queue holds before callers have to wait.
+func WithQueueDepth(depth int) ClientOption {
+	return func(c *APIClient) {
+		c.QueueDepth = depth
+	}
+}
+
+// WithQueueTimeout sets how long a caller waits for a free queue slot before failing.
+func WithQueueTimeout(timeout time.Duration) ClientOption {
+	return func(c *APIClient) {
+		c.QueueTimeout = timeout
+	}
+}
+
+// WithRateLimit limits the requests sent by the client to requestsPerSecond on average,
+// allowing up to burst requests at once.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *APIClient) {
+		c.RateLimiter = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithAuthenticator replaces the default API key and admin password authentication.
+// Use NewDefaultAuthenticator in a MultiAuthenticator to keep sending those headers.
+func WithAuthenticator(authenticator Authenticator) ClientOption {
+	return func(c *APIClient) {
+		c.Authenticator = authenticator
+	}
+}
+
+// WithStrictDecoding reports response properties that the models have no field for as
+// errors, so that changes of the Naming Tool API are detected in tests.
+func WithStrictDecoding(enabled bool) ClientOption {
+	return func(c *APIClient) {
+		c.StrictDecoding = enabled
+	}
+}
+
+// NewDefaultAuthenticator returns the authenticator sending the API key and, if set, the admin password.
+//
+// Parameters:
+//   - apiKey: The API key of the Naming Tool.
+//   - adminPassword: The admin password of the Naming Tool, or an empty string.
+//
+// Returns:
+//   - An Authenticator setting the APIKey and AdminPassword headers.
+func NewDefaultAuthenticator(apiKey string, adminPassword string) Authenticator {
+	authenticators := MultiAuthenticator{&APIKeyAuthenticator{APIKey: apiKey}}
+	if adminPassword != "" {
+		authenticators = append(authenticators, &AdminPasswordAuthenticator{Password: adminPassword})
+	}
+	return authenticators
+}
+
+// concurrencyClass groups requests that share a queue and its workers.
+type concurrencyClass int
+
+const (
+	// concurrencyRead is used for requests without side effects, which run on Parallelism workers.
+	concurrencyRead concurrencyClass = iota
+	// concurrencySerial is used for requests that write to the Naming Tool storage, including
+	// name generation, which run one at a time so the tool can reliably detect duplicates.
+	concurrencySerial
+)
+
+// String returns the name of the queue of the concurrency class, as used in metrics.
+func (c concurrencyClass) String() string {
+	if c == concurrencySerial {
+		return "serial"
+	}
+	return "read"
 }
 
 type requestEntry struct {
-	req  *http.Request
-	resp chan responseEntry
+	req      *http.Request
+	resp     chan responseEntry
+	class    concurrencyClass
+	enqueued time.Time
 }
 
 type responseEntry struct {
@@ -26,12 +156,18 @@ type responseEntry struct {
 }
 
 // NewAPIClient creates a new instance of APIClient with the provided base URL and API key.
-func NewAPIClient(baseURL string, apiKey string, adminPassword string, httpClient *http.Client) *APIClient {
-	if baseURL == "" {
-		panic("baseURL cannot be empty")
+// Optional settings such as the read parallelism can be changed with opts.
+// The client starts background workers that are stopped by Close.
+//
+// Returns:
+//   - A pointer to the newly created APIClient.
+//   - An error matching utils.ErrInvalidBaseURL or utils.ErrMissingAPIKey if the settings are invalid.
+func NewAPIClient(baseURL string, apiKey string, adminPassword string, httpClient *http.Client, opts ...ClientOption) (*APIClient, error) {
+	if err := validateBaseURL(baseURL); err != nil {
+		return nil, err
 	}
 	if apiKey == "" {
-		panic("apiKey cannot be empty")
+		return nil, fmt.Errorf("%w: the API key cannot be empty", utils.ErrMissingAPIKey)
 	}
 
 	if httpClient == nil {
@@ -39,87 +175,178 @@ func NewAPIClient(baseURL string, apiKey string, adminPassword string, httpClien
 	}
 
 	client := &APIClient{
-		BaseURL:       baseURL,
-		APIKey:        apiKey,
-		AdminPassword: adminPassword,
-		ApiEndpoints: map[string]string{
-			//Resource Naming
-			"RequestName":               baseURL + "/api/ResourceNamingRequests/RequestName",
-			"RequestNameWithComponents": baseURL + "/api/ResourceNamingRequests/RequestNameWithComponents",
-			"ValidateName":              baseURL + "/api/ResourceNamingRequests/ValidateName",
-			"GetGeneratedName":          baseURL + "/api/Admin/GetGeneratedName/{id}",
-			"DeleteGeneratedName":       baseURL + "/api/Admin/DeleteGeneratedName/{id}",
-
-			// Custom Components
-			"GetAllCustomComponents":          baseURL + "/api/CustomComponents",
-			"GetCustomComponent":              baseURL + "/api/CustomComponents/{id}",
-			"GetCustomComponentByParentId":    baseURL + "/api/CustomComponents/GetByParentId/{parentComponentId}",
-			"GetCustomComponentByParentType":  baseURL + "/api/CustomComponents/GetByParentType/{parentComponentType}",
-			"CreateOrUpdateCustomComponent":   baseURL + "/api/CustomComponents",
-			"DeleteCustomComponent":           baseURL + "/api/CustomComponents/{id}",
-			"DeleteCustomComponentByParentId": baseURL + "/api/CustomComponents/DeleteByParentId/{parentComponentId}",
-
-			// Resource Components
-			"GetAllResourceComponents":        baseURL + "/api/ResourceComponents",
-			"GetResourceComponent":            baseURL + "/api/ResourceComponents/{id}",
-			"CreateOrUpdateResourceComponent": baseURL + "/api/ResourceComponents",
-
-			// Resource Delimiters
-			"GetAllResourceDelimiters":        baseURL + "/api/ResourceDelimiters",
-			"GetResourceDelimiter":            baseURL + "/api/ResourceDelimiters/{id}",
-			"CreateOrUpdateResourceDelimiter": baseURL + "/api/ResourceDelimiters",
-
-			// Resource Environments
-			"GetAllResourceEnvironments":        baseURL + "/api/ResourceEnvironments",
-			"CreateOrUpdateResourceEnvironment": baseURL + "/api/ResourceEnvironments",
-			"DeleteResourceEnvironment":         baseURL + "/api/ResourceEnvironments/{id}",
-
-			// Resource Functions
-			"GetAllResourceFunctions":        baseURL + "/api/ResourceFunctions",
-			"GetResourceFunction":            baseURL + "/api/ResourceFunctions/{id}",
-			"CreateOrUpdateResourceFunction": baseURL + "/api/ResourceFunctions",
-			"DeleteResourceFunction":         baseURL + "/api/ResourceFunctions/{id}",
-
-			// Resource Locations
-			"GetAllResourceLocations":        baseURL + "/api/ResourceLocations",
-			"GetResourceLocation":            baseURL + "/api/ResourceLocations/{id}",
-			"CreateOrUpdateResourceLocation": baseURL + "/api/ResourceLocations",
-			"DeleteResourceLocation":         baseURL + "/api/ResourceLocations/{id}",
-
-			// Resource Types
-			"GetAllResourceTypes": baseURL + "/api/ResourceTypes",
-
-			//Resource Units
-			"CreateOrUpdateResourceUnit": baseURL + "/api/ResourceUnitDepts",
-			"DeleteResourceUnit":         baseURL + "/api/ResourceUnitDepts/{id}",
-		},
-		HttpClient:   httpClient,
-		requestQueue: make(chan requestEntry, 100), // Buffered channel to queue requests
-	}
-
-	go client.processQueue()
-
-	return client
-}
-
-// processQueue processes the queued requests one by one.
-func (c *APIClient) processQueue() {
-	for entry := range c.requestQueue {
+		BaseURL:             normalizeBaseURL(baseURL),
+		APIKey:              apiKey,
+		AdminPassword:       adminPassword,
+		HttpClient:          httpClient,
+		RetryPolicy:         DefaultRetryPolicy(),
+		Parallelism:         DefaultParallelism,
+		QueueDepth:          DefaultQueueDepth,
+		QueueTimeout:        DefaultQueueTimeout,
+		Metrics:             NewMetrics(),
+		HealthCheckInterval: DefaultHealthCheckInterval,
+		CreatedBy:           DefaultCreatedBy,
+		AdoptionWindow:      DefaultAdoptionWindow,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.instances = []*instance{{baseURL: client.BaseURL, healthy: true}}
+	for i, failoverURL := range client.FailoverURLs {
+		if err := validateBaseURL(failoverURL); err != nil {
+			return nil, err
+		}
+		client.FailoverURLs[i] = normalizeBaseURL(failoverURL)
+		client.instances = append(client.instances, &instance{baseURL: client.FailoverURLs[i], healthy: true})
+	}
+	if client.Authenticator == nil {
+		client.Authenticator = NewDefaultAuthenticator(apiKey, adminPassword)
+	}
+	if client.Parallelism < 1 {
+		client.Parallelism = 1
+	}
+	if client.QueueDepth < 1 {
+		client.QueueDepth = 1
+	}
+
+	client.readQueue = make(chan requestEntry, client.QueueDepth)
+	client.serialQueue = make(chan requestEntry, client.QueueDepth)
+
+	client.workers.Add(client.Parallelism + 1)
+	for i := 0; i < client.Parallelism; i++ {
+		go client.processQueue(client.readQueue)
+	}
+	go client.processQueue(client.serialQueue)
+
+	return client, nil
+}
+
+// validateBaseURL checks that baseURL is an absolute http or https URL. It may have a path
+// prefix, such as https://example.com/namingtool, but no query or fragment.
+func validateBaseURL(baseURL string) error {
+	if baseURL == "" {
+		return fmt.Errorf("%w: the base URL cannot be empty", utils.ErrInvalidBaseURL)
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("%w: %s", utils.ErrInvalidBaseURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%w: %q must start with http:// or https://", utils.ErrInvalidBaseURL, baseURL)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("%w: %q has no host", utils.ErrInvalidBaseURL, baseURL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("%w: %q cannot have a query or fragment", utils.ErrInvalidBaseURL, baseURL)
+	}
+	return nil
+}
+
+// Close stops accepting requests, waits for the queued requests to be processed and
+// stops the queue workers, then writes the metrics to MetricsFile if it is set. Calling
+// Close more than once has no effect.
+//
+// Returns:
+//   - Always nil. The error is returned to implement io.Closer.
+func (c *APIClient) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.readQueue)
+	close(c.serialQueue)
+	c.mu.Unlock()
+
+	c.workers.Wait()
+	c.HttpClient.CloseIdleConnections()
+	c.metricsChanged.Store(false)
+	c.writeMetrics(context.Background())
+	return nil
+}
+
+// writeMetrics writes the metrics to MetricsFile if it is set. Failures are logged, since
+// metrics must not fail the requests.
+func (c *APIClient) writeMetrics(ctx context.Context) {
+	if c.MetricsFile == "" || c.Metrics == nil {
+		return
+	}
+	if err := c.Metrics.WriteFile(c.MetricsFile); err != nil {
+		tflog.Warn(ctx, "Failed to write Naming Tool client metrics", map[string]interface{}{"path": c.MetricsFile, "error": err.Error()})
+	}
+}
+
+// FlushMetrics writes the metrics to MetricsFile if requests completed since they were last
+// written. Callers flush after each unit of work, since the process using the client may be
+// stopped without Close being called. Failures are logged.
+//
+// Parameters:
+//   - ctx: The context used for logging.
+func (c *APIClient) FlushMetrics(ctx context.Context) {
+	if c.metricsChanged.Swap(false) {
+		c.writeMetrics(ctx)
+	}
+}
+
+// processQueue processes the requests of one queue in order.
+// Requests whose context was cancelled while waiting in the queue or for the
+// rate limiter are not sent.
+func (c *APIClient) processQueue(queue chan requestEntry) {
+	defer c.workers.Done()
+
+	for entry := range queue {
+		if err := entry.req.Context().Err(); err != nil {
+			entry.resp <- responseEntry{err: err}
+			close(entry.resp)
+			continue
+		}
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(entry.req.Context()); err != nil {
+				entry.resp <- responseEntry{err: err}
+				close(entry.resp)
+				continue
+			}
+		}
+		wait := time.Since(entry.enqueued)
+		c.Metrics.observeQueueWait(entry.class.String(), wait)
+		trace.SpanFromContext(entry.req.Context()).AddEvent("dequeued", trace.WithAttributes(
+			attribute.String("naming_tool.queue", entry.class.String()),
+			attribute.Float64("naming_tool.queue_wait_seconds", wait.Seconds()),
+		))
+
 		resp, err := c.doRequest(entry.req)
+		c.metricsChanged.Store(true)
 		entry.resp <- responseEntry{resp: resp, err: err}
 		close(entry.resp)
 	}
 }
 
-// doRequest sends an HTTP request using the client's HTTP client, adding the API key to the request headers.
+// doRequest sends an HTTP request using the client's HTTP client, adding the credentials of the client's Authenticator.
 func (c *APIClient) doRequest(req *http.Request) (*http.Response, error) {
-	req.Header.Set("APIKey", c.APIKey)
-
-	if c.AdminPassword != "" {
-		req.Header.Set("AdminPassword", c.AdminPassword)
+	if err := c.Authenticator.Authenticate(req.Context(), req); err != nil {
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
 	}
 
+	ctx := c.logContext(req.Context())
+	c.logRequest(ctx, req)
+
+	start := time.Now()
 	resp, err := c.HttpClient.Do(req)
+	duration := time.Since(start)
+	c.logResponse(ctx, req, resp, err, duration)
+
+	endpointName := "unknown"
+	if endpoint, ok := EndpointFromContext(req.Context()); ok {
+		endpointName = endpoint.Name
+	}
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	c.Metrics.observeRequest(endpointName, statusCode, err, duration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -127,18 +354,76 @@ func (c *APIClient) doRequest(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
-// DoRequest adds the request to the queue to be processed sequentially.
-func (c *APIClient) DoRequest(req *http.Request) (*http.Response, error) {
+// DoRequest adds the request to the queue of its concurrency class. Reads run in
+// parallel on Parallelism workers while every other request is processed sequentially.
+// It returns early with the context error if ctx is cancelled while the request
+// is waiting to be queued or waiting for its response, and with utils.ErrQueueFull
+// if no queue slot frees up within QueueTimeout. After Close it fails with utils.ErrClientClosed.
+func (c *APIClient) DoRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 
-	// Create a copy of the request to avoid concurrent modifications
-	reqCopy := req.Clone(req.Context())
+	// Create a copy of the request bound to ctx to avoid concurrent modifications
+	reqCopy := req.Clone(ctx)
 
-	respChan := make(chan responseEntry)
-	c.requestQueue <- requestEntry{req: reqCopy, resp: respChan}
+	// Buffered so the worker never blocks on a caller that already gave up
+	respChan := make(chan responseEntry, 1)
+	class := classifyRequest(reqCopy)
+	queue := c.readQueue
+	if class == concurrencySerial {
+		queue = c.serialQueue
+	}
+
+	var timeout <-chan time.Time
+	if c.QueueTimeout > 0 {
+		timer := time.NewTimer(c.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	if err := c.enqueue(ctx, queue, requestEntry{req: reqCopy, resp: respChan, class: class, enqueued: time.Now()}, timeout); err != nil {
+		return nil, err
+	}
 
-	result := <-respChan
-	return result.resp, result.err
+	select {
+	case result := <-respChan:
+		return result.resp, result.err
+	case <-ctx.Done():
+		// Release the response body if the worker still completes the request
+		go func() {
+			if result := <-respChan; result.resp != nil {
+				result.resp.Body.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// enqueue adds entry to queue, holding the read lock so Close cannot close the queue meanwhile.
+func (c *APIClient) enqueue(ctx context.Context, queue chan requestEntry, entry requestEntry, timeout <-chan time.Time) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return utils.ErrClientClosed
+	}
+
+	select {
+	case queue <- entry:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		return fmt.Errorf("%w: %d requests still waiting after %s", utils.ErrQueueFull, len(queue), c.QueueTimeout)
+	}
+}
+
+// classifyRequest returns the concurrency class of a request based on its method.
+func classifyRequest(req *http.Request) concurrencyClass {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return concurrencyRead
+	}
+	return concurrencySerial
 }
@@ -0,0 +1,190 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient creates a client for serverURL that is closed when the test finishes.
+func newTestClient(t *testing.T, serverURL string, httpClient *http.Client, opts ...ClientOption) *APIClient {
+	client, err := NewAPIClient(serverURL, "123456", "123456", httpClient, opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestDoRequestCancelled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(t, server.URL, server.Client())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	start := time.Now()
+	_, err := client.DoRequest(ctx, req)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+// concurrencyServer records the highest number of requests it handled at the same time.
+type concurrencyServer struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (s *concurrencyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.current++
+	if s.current > s.max {
+		s.max = s.current
+	}
+	s.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	s.mu.Lock()
+	s.current--
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func runConcurrently(t *testing.T, client *APIClient, method string, url string, count int) {
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(method, url, nil)
+			resp, err := client.DoRequest(context.Background(), req)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDoRequestConcurrencyClasses(t *testing.T) {
+	handler := &concurrencyServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, server.Client(), WithParallelism(4))
+
+	runConcurrently(t, client, http.MethodGet, server.URL, 8)
+	assert.Greater(t, handler.max, 1)
+	assert.LessOrEqual(t, handler.max, 4)
+
+	handler.max = 0
+	runConcurrently(t, client, http.MethodPost, server.URL, 4)
+	assert.Equal(t, 1, handler.max)
+}
+
+func TestDoRequestQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(t, server.URL, server.Client(),
+		WithParallelism(1), WithQueueDepth(1), WithQueueTimeout(20*time.Millisecond))
+
+	// One request is in flight and one waits in the queue, so the third cannot be queued.
+	for i := 0; i < 2; i++ {
+		go func() {
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			client.DoRequest(context.Background(), req)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := client.DoRequest(context.Background(), req)
+
+	assert.ErrorIs(t, err, utils.ErrQueueFull)
+}
+
+func TestNewAPIClientValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		apiKey  string
+		err     error
+	}{
+		{"empty base URL", "", "123456", utils.ErrInvalidBaseURL},
+		{"missing scheme", "namingtool.example.com", "123456", utils.ErrInvalidBaseURL},
+		{"unsupported scheme", "ftp://namingtool.example.com", "123456", utils.ErrInvalidBaseURL},
+		{"unparsable URL", "http://namingtool example.com:port", "123456", utils.ErrInvalidBaseURL},
+		{"empty API key", "https://namingtool.example.com", "", utils.ErrMissingAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewAPIClient(tt.baseURL, tt.apiKey, "", nil)
+
+			assert.Nil(t, client)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCloseDrainsQueue(t *testing.T) {
+	var served int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&served, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewAPIClient(server.URL, "123456", "", server.Client())
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+			if resp, err := client.DoRequest(context.Background(), req); err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	assert.NoError(t, client.Close())
+	wg.Wait()
+	assert.NoError(t, client.Close())
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err = client.DoRequest(context.Background(), req)
+	assert.ErrorIs(t, err, utils.ErrClientClosed)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&served))
+}
@@ -1,6 +1,11 @@
 package apiclient
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
 )
 
@@ -21,12 +26,15 @@ func NewCustomComponentService(client *APIClient) *CustomComponentService {
 
 // GetAllCustomComponents retrieves all custom components.
 //
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//
 // Returns:
 //   - A pointer to a slice of models.CustomComponent containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *CustomComponentService) GetAllCustomComponents() (*[]models.CustomComponent, error) {
+func (s *CustomComponentService) GetAllCustomComponents(ctx context.Context) (*[]models.CustomComponent, error) {
 	var response []models.CustomComponent
-	err := s.baseService.DoGet("GetAllCustomComponents", nil, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetAllCustomComponents, nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -37,14 +45,15 @@ func (s *CustomComponentService) GetAllCustomComponents() (*[]models.CustomCompo
 // GetCustomComponent retrieves a custom component based on the provided ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - id: A string representing the ID of the custom component.
 //
 // Returns:
 //   - A pointer to models.CustomComponent containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *CustomComponentService) GetCustomComponent(id string) (*models.CustomComponent, error) {
+func (s *CustomComponentService) GetCustomComponent(ctx context.Context, id string) (*models.CustomComponent, error) {
 	var response models.CustomComponent
-	err := s.baseService.DoGet("GetCustomComponent", map[string]string{"id": id}, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetCustomComponent, map[string]string{"id": id}, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -55,14 +64,15 @@ func (s *CustomComponentService) GetCustomComponent(id string) (*models.CustomCo
 // GetCustomComponentByParentId retrieves a custom component based on the provided parent component ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - parentComponentId: A string representing the ID of the parent custom component.
 //
 // Returns:
 //   - A pointer to models.CustomComponent containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *CustomComponentService) GetCustomComponentByParentId(parentComponentId string) (*models.CustomComponent, error) {
+func (s *CustomComponentService) GetCustomComponentByParentId(ctx context.Context, parentComponentId string) (*models.CustomComponent, error) {
 	var response models.CustomComponent
-	err := s.baseService.DoGet("GetCustomComponentByParentId", map[string]string{"parentComponentId": parentComponentId}, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetCustomComponentByParentId, map[string]string{"parentComponentId": parentComponentId}, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -73,14 +83,15 @@ func (s *CustomComponentService) GetCustomComponentByParentId(parentComponentId
 // GetCustomComponentByParentType retrieves custom components based on the provided parent type.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - parentType: A string representing the type of the parent custom component.
 //
 // Returns:
 //   - A pointer to a slice of models.CustomComponent containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *CustomComponentService) GetCustomComponentByParentType(parentType string) (*[]models.CustomComponent, error) {
+func (s *CustomComponentService) GetCustomComponentByParentType(ctx context.Context, parentType string) (*[]models.CustomComponent, error) {
 	var response []models.CustomComponent
-	err := s.baseService.DoGet("GetCustomComponentByParentType", map[string]string{"parentType": parentType}, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetCustomComponentByParentType, map[string]string{"parentComponentType": parentType}, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -91,40 +102,102 @@ func (s *CustomComponentService) GetCustomComponentByParentType(parentType strin
 // CreateOrUpdateCustomComponent creates or updates a custom component based on the provided request data.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - request: An instance of models.CustomComponent containing the request data.
 //
 // Returns:
-//   - A pointer to models.CustomComponent containing the response data.
+//   - A pointer to models.CustomComponent containing the response data, without ID if the
+//     Naming Tool did not answer with the custom component.
 //   - An error if the request fails or the response indicates failure.
-func (s *CustomComponentService) CreateOrUpdateCustomComponent(request models.CustomComponent) (*models.CustomComponent, error) {
-	var response models.CustomComponent
-	err := s.baseService.DoPost("CreateOrUpdateCustomComponent", request, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+func (s *CustomComponentService) CreateOrUpdateCustomComponent(ctx context.Context, request models.CustomComponent) (*models.CustomComponent, error) {
+	return postWrite[models.CustomComponent](ctx, s.baseService, EndpointCreateOrUpdateCustomComponent, request)
 }
 
 // DeleteCustomComponent deletes a custom component based on the provided ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - id: A string representing the ID of the custom component.
 //
 // Returns:
 //   - An interface containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *CustomComponentService) DeleteCustomComponent(id string) error {
-	return s.baseService.DoDelete("DeleteCustomComponent", map[string]string{"id": id})
+func (s *CustomComponentService) DeleteCustomComponent(ctx context.Context, id string) error {
+	return s.baseService.DoDelete(ctx, EndpointDeleteCustomComponent, map[string]string{"id": id})
 }
 
 // DeleteCustomComponentByParentId deletes custom components based on the provided parent component ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - parentComponentId: A string representing the ID of the parent custom component.
 //
 // Returns:
 //   - An error if the request fails or the response indicates failure.
-func (s *CustomComponentService) DeleteCustomComponentByParentId(parentComponentId string) error {
-	return s.baseService.DoDelete("DeleteCustomComponentByParentId", map[string]string{"parentComponentId": parentComponentId})
+func (s *CustomComponentService) DeleteCustomComponentByParentId(ctx context.Context, parentComponentId string) error {
+	return s.baseService.DoDelete(ctx, EndpointDeleteCustomComponentByParentId, map[string]string{"parentComponentId": parentComponentId})
+}
+
+// ApplyCustomComponents makes the values of a custom component match desired, sending only the
+// values that differ. Values are matched by name, ignoring case. Values missing from the Naming
+// Tool are created and values missing from desired are deleted.
+//
+// Parameters:
+//   - ctx: The context used to cancel the requests.
+//   - parentComponent: The name of the parent custom component.
+//   - desired: The complete list of values. Their IDs and parent components are ignored.
+//
+// Returns:
+//   - A slice of models.CustomComponent with the values of the parent component after the updates.
+//   - An error if desired contains a name twice or a request fails.
+func (s *CustomComponentService) ApplyCustomComponents(ctx context.Context, parentComponent string, desired []models.CustomComponent) ([]models.CustomComponent, error) {
+	current, err := s.GetCustomComponentByParentType(ctx, parentComponent)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]models.CustomComponent, len(*current))
+	for _, value := range *current {
+		existing[strings.ToLower(value.Name)] = value
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	var changed []models.CustomComponent
+	for _, value := range desired {
+		key := strings.ToLower(value.Name)
+		if wanted[key] {
+			return nil, fmt.Errorf("value %q of custom component %q is listed more than once", value.Name, parentComponent)
+		}
+		wanted[key] = true
+
+		old, ok := existing[key]
+		value.Id = old.Id
+		value.ParentComponent = parentComponent
+		if ok {
+			value.ParentComponent = old.ParentComponent
+			if old == value {
+				continue
+			}
+		}
+		changed = append(changed, value)
+	}
+
+	for _, value := range *current {
+		if wanted[strings.ToLower(value.Name)] {
+			continue
+		}
+		if err := s.DeleteCustomComponent(ctx, strconv.FormatInt(value.Id, 10)); err != nil {
+			return nil, fmt.Errorf("failed to delete value %q of custom component %q: %w", value.Name, parentComponent, err)
+		}
+	}
+	for _, value := range changed {
+		if _, err := s.CreateOrUpdateCustomComponent(ctx, value); err != nil {
+			return nil, fmt.Errorf("failed to update value %q of custom component %q: %w", value.Name, parentComponent, err)
+		}
+	}
+
+	updated, err := s.GetCustomComponentByParentType(ctx, parentComponent)
+	if err != nil {
+		return nil, err
+	}
+	return *updated, nil
 }
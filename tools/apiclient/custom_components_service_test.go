@@ -0,0 +1,40 @@
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCustomComponents(t *testing.T) {
+	server := namingtooltest.NewServer(t)
+	server.Put(namingtooltest.CustomComponents,
+		models.CustomComponent{Id: 1, ParentComponent: "costcenter", Name: "Finance", ShortName: "fin", SortOrder: 1, MinLength: 1, MaxLength: 5},
+		models.CustomComponent{Id: 2, ParentComponent: "costcenter", Name: "Marketing", ShortName: "mkt", SortOrder: 2, MinLength: 1, MaxLength: 5},
+		models.CustomComponent{Id: 3, ParentComponent: "costcenter", Name: "Legal", ShortName: "lgl", SortOrder: 3, MinLength: 1, MaxLength: 5},
+		models.CustomComponent{Id: 4, ParentComponent: "project", Name: "Legal", ShortName: "lgl", SortOrder: 1, MinLength: 1, MaxLength: 5},
+	)
+
+	client := newTestClient(t, server.URL, server.Client())
+	values, err := NewCustomComponentService(client).ApplyCustomComponents(context.Background(), "costcenter", []models.CustomComponent{
+		{Name: "finance", ShortName: "fin", SortOrder: 1, MinLength: 1, MaxLength: 5},
+		{Name: "Marketing", ShortName: "mk", SortOrder: 2, MinLength: 1, MaxLength: 5},
+		{Name: "Sales", ShortName: "sls", SortOrder: 3, MinLength: 1, MaxLength: 5},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, values, 3)
+
+	// Finance only differs in the case of its name, Legal is deleted in costcenter only
+	assert.Equal(t, []int64{3}, server.Deleted(namingtooltest.CustomComponents))
+	posted := namingtooltest.Posted[models.CustomComponent](server, namingtooltest.CustomComponents)
+	if assert.Len(t, posted, 3) {
+		assert.Equal(t, models.CustomComponent{Id: 1, ParentComponent: "costcenter", Name: "finance", ShortName: "fin", SortOrder: 1, MinLength: 1, MaxLength: 5}, posted[0])
+		assert.Equal(t, "mk", posted[1].ShortName)
+		assert.Equal(t, models.CustomComponent{Id: 5, ParentComponent: "costcenter", Name: "Sales", ShortName: "sls", SortOrder: 3, MinLength: 1, MaxLength: 5}, posted[2])
+	}
+	_, kept := namingtooltest.Record[models.CustomComponent](server, namingtooltest.CustomComponents, 4)
+	assert.True(t, kept)
+}
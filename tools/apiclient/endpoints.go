@@ -0,0 +1,212 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// AuthLevel is the credential a Naming Tool endpoint requires.
+type AuthLevel int
+
+const (
+	// AuthAPIKey endpoints only need the API key.
+	AuthAPIKey AuthLevel = iota
+	// AuthAdmin endpoints also need the admin password.
+	AuthAdmin
+)
+
+// Endpoint describes an operation of the Naming Tool API. Path is the path served by the
+// releases of the compatibility matrix, 4.2.0 and 4.2.1, and Variants hold the paths of the
+// releases that moved the operation, chosen by the detected or configured server version.
+type Endpoint struct {
+	Name      string            // The name used in logs and errors.
+	Method    string            // The HTTP method of the operation.
+	Path      string            // The path relative to the base URL, with {param} placeholders.
+	Params    []string          // The parameters required to fill the placeholders of Path.
+	Auth      AuthLevel         // The credential the operation requires.
+	Variants  []EndpointVariant // The paths of the endpoint by Naming Tool release, if it moved.
+	Optional  bool              // Whether the Naming Tool may not serve the operation. A 404 is then final and not retried.
+	Cacheable bool              // Whether responses are kept in the client's ResponseCache until a write to the same collection.
+}
+
+// placeholderPattern matches the {param} placeholders of an endpoint path.
+var placeholderPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+var (
+	// Server
+	//
+	// The version path is not part of the documented Naming Tool API. It is only served by
+	// deployments that report their version, for example through a gateway in front of the
+	// Naming Tool, and the others answer 404.
+	EndpointGetServerVersion = Endpoint{Name: "GetServerVersion", Method: http.MethodGet, Path: "/api/Admin/GetVersion", Optional: true}
+
+	// Resource Naming
+	EndpointRequestName               = Endpoint{Name: "RequestName", Method: http.MethodPost, Path: "/api/ResourceNamingRequests/RequestName"}
+	EndpointRequestNameWithComponents = Endpoint{Name: "RequestNameWithComponents", Method: http.MethodPost, Path: "/api/ResourceNamingRequests/RequestNameWithComponents"}
+	EndpointValidateName              = Endpoint{Name: "ValidateName", Method: http.MethodPost, Path: "/api/ResourceNamingRequests/ValidateName"}
+	EndpointGetAllGeneratedNames      = Endpoint{Name: "GetAllGeneratedNames", Method: http.MethodGet, Path: "/api/Admin/GetGeneratedNames", Auth: AuthAdmin}
+	EndpointGetGeneratedName          = Endpoint{Name: "GetGeneratedName", Method: http.MethodGet, Path: "/api/Admin/GetGeneratedName/{id}", Params: []string{"id"}, Auth: AuthAdmin}
+	EndpointDeleteGeneratedName       = Endpoint{Name: "DeleteGeneratedName", Method: http.MethodDelete, Path: "/api/Admin/DeleteGeneratedName/{id}", Params: []string{"id"}, Auth: AuthAdmin}
+
+	// Custom Components
+	EndpointGetAllCustomComponents          = Endpoint{Name: "GetAllCustomComponents", Method: http.MethodGet, Path: "/api/CustomComponents", Cacheable: true}
+	EndpointGetCustomComponent              = Endpoint{Name: "GetCustomComponent", Method: http.MethodGet, Path: "/api/CustomComponents/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointGetCustomComponentByParentId    = Endpoint{Name: "GetCustomComponentByParentId", Method: http.MethodGet, Path: "/api/CustomComponents/GetByParentId/{parentComponentId}", Params: []string{"parentComponentId"}, Cacheable: true}
+	EndpointGetCustomComponentByParentType  = Endpoint{Name: "GetCustomComponentByParentType", Method: http.MethodGet, Path: "/api/CustomComponents/GetByParentType/{parentComponentType}", Params: []string{"parentComponentType"}, Cacheable: true}
+	EndpointCreateOrUpdateCustomComponent   = Endpoint{Name: "CreateOrUpdateCustomComponent", Method: http.MethodPost, Path: "/api/CustomComponents"}
+	EndpointDeleteCustomComponent           = Endpoint{Name: "DeleteCustomComponent", Method: http.MethodDelete, Path: "/api/CustomComponents/{id}", Params: []string{"id"}}
+	EndpointDeleteCustomComponentByParentId = Endpoint{Name: "DeleteCustomComponentByParentId", Method: http.MethodDelete, Path: "/api/CustomComponents/DeleteByParentId/{parentComponentId}", Params: []string{"parentComponentId"}}
+
+	// Resource Components
+	EndpointGetAllResourceComponents        = Endpoint{Name: "GetAllResourceComponents", Method: http.MethodGet, Path: "/api/ResourceComponents", Cacheable: true}
+	EndpointGetResourceComponent            = Endpoint{Name: "GetResourceComponent", Method: http.MethodGet, Path: "/api/ResourceComponents/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceComponent = Endpoint{Name: "CreateOrUpdateResourceComponent", Method: http.MethodPost, Path: "/api/ResourceComponents"}
+
+	// Resource Delimiters
+	EndpointGetAllResourceDelimiters        = Endpoint{Name: "GetAllResourceDelimiters", Method: http.MethodGet, Path: "/api/ResourceDelimiters", Cacheable: true}
+	EndpointGetResourceDelimiter            = Endpoint{Name: "GetResourceDelimiter", Method: http.MethodGet, Path: "/api/ResourceDelimiters/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceDelimiter = Endpoint{Name: "CreateOrUpdateResourceDelimiter", Method: http.MethodPost, Path: "/api/ResourceDelimiters"}
+
+	// Resource Environments
+	EndpointGetAllResourceEnvironments        = Endpoint{Name: "GetAllResourceEnvironments", Method: http.MethodGet, Path: "/api/ResourceEnvironments", Cacheable: true}
+	EndpointGetResourceEnvironment            = Endpoint{Name: "GetResourceEnvironment", Method: http.MethodGet, Path: "/api/ResourceEnvironments/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceEnvironment = Endpoint{Name: "CreateOrUpdateResourceEnvironment", Method: http.MethodPost, Path: "/api/ResourceEnvironments"}
+	EndpointDeleteResourceEnvironment         = Endpoint{Name: "DeleteResourceEnvironment", Method: http.MethodDelete, Path: "/api/ResourceEnvironments/{id}", Params: []string{"id"}}
+
+	// Resource Functions
+	EndpointGetAllResourceFunctions        = Endpoint{Name: "GetAllResourceFunctions", Method: http.MethodGet, Path: "/api/ResourceFunctions", Cacheable: true}
+	EndpointGetResourceFunction            = Endpoint{Name: "GetResourceFunction", Method: http.MethodGet, Path: "/api/ResourceFunctions/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceFunction = Endpoint{Name: "CreateOrUpdateResourceFunction", Method: http.MethodPost, Path: "/api/ResourceFunctions"}
+	EndpointDeleteResourceFunction         = Endpoint{Name: "DeleteResourceFunction", Method: http.MethodDelete, Path: "/api/ResourceFunctions/{id}", Params: []string{"id"}}
+
+	// Resource Locations
+	EndpointGetAllResourceLocations        = Endpoint{Name: "GetAllResourceLocations", Method: http.MethodGet, Path: "/api/ResourceLocations", Cacheable: true}
+	EndpointGetResourceLocation            = Endpoint{Name: "GetResourceLocation", Method: http.MethodGet, Path: "/api/ResourceLocations/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceLocation = Endpoint{Name: "CreateOrUpdateResourceLocation", Method: http.MethodPost, Path: "/api/ResourceLocations"}
+	EndpointDeleteResourceLocation         = Endpoint{Name: "DeleteResourceLocation", Method: http.MethodDelete, Path: "/api/ResourceLocations/{id}", Params: []string{"id"}}
+
+	// Resource Organizations
+	EndpointGetAllResourceOrganizations        = Endpoint{Name: "GetAllResourceOrganizations", Method: http.MethodGet, Path: "/api/ResourceOrgs", Cacheable: true}
+	EndpointGetResourceOrganization            = Endpoint{Name: "GetResourceOrganization", Method: http.MethodGet, Path: "/api/ResourceOrgs/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceOrganization = Endpoint{Name: "CreateOrUpdateResourceOrganization", Method: http.MethodPost, Path: "/api/ResourceOrgs"}
+	EndpointDeleteResourceOrganization         = Endpoint{Name: "DeleteResourceOrganization", Method: http.MethodDelete, Path: "/api/ResourceOrgs/{id}", Params: []string{"id"}}
+
+	// Resource Projects
+	EndpointGetAllResourceProjects        = Endpoint{Name: "GetAllResourceProjects", Method: http.MethodGet, Path: "/api/ResourceProjAppSvcs", Cacheable: true}
+	EndpointGetResourceProject            = Endpoint{Name: "GetResourceProject", Method: http.MethodGet, Path: "/api/ResourceProjAppSvcs/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceProject = Endpoint{Name: "CreateOrUpdateResourceProject", Method: http.MethodPost, Path: "/api/ResourceProjAppSvcs"}
+	EndpointDeleteResourceProject         = Endpoint{Name: "DeleteResourceProject", Method: http.MethodDelete, Path: "/api/ResourceProjAppSvcs/{id}", Params: []string{"id"}}
+
+	// Resource Types
+	EndpointGetAllResourceTypes     = Endpoint{Name: "GetAllResourceTypes", Method: http.MethodGet, Path: "/api/ResourceTypes", Cacheable: true}
+	EndpointGetResourceType         = Endpoint{Name: "GetResourceType", Method: http.MethodGet, Path: "/api/ResourceTypes/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointPostResourceTypesConfig = Endpoint{Name: "PostResourceTypesConfig", Method: http.MethodPost, Path: "/api/ResourceTypes/PostConfig"}
+
+	// Resource Units
+	EndpointGetAllResourceUnits        = Endpoint{Name: "GetAllResourceUnits", Method: http.MethodGet, Path: "/api/ResourceUnitDepts", Cacheable: true}
+	EndpointGetResourceUnit            = Endpoint{Name: "GetResourceUnit", Method: http.MethodGet, Path: "/api/ResourceUnitDepts/{id}", Params: []string{"id"}, Cacheable: true}
+	EndpointCreateOrUpdateResourceUnit = Endpoint{Name: "CreateOrUpdateResourceUnit", Method: http.MethodPost, Path: "/api/ResourceUnitDepts"}
+	EndpointDeleteResourceUnit         = Endpoint{Name: "DeleteResourceUnit", Method: http.MethodDelete, Path: "/api/ResourceUnitDepts/{id}", Params: []string{"id"}}
+)
+
+// Endpoints returns every endpoint of the Naming Tool API known to the client.
+func Endpoints() []Endpoint {
+	return []Endpoint{
+		EndpointGetServerVersion,
+		EndpointRequestName, EndpointRequestNameWithComponents, EndpointValidateName, EndpointGetAllGeneratedNames, EndpointGetGeneratedName, EndpointDeleteGeneratedName,
+		EndpointGetAllCustomComponents, EndpointGetCustomComponent, EndpointGetCustomComponentByParentId, EndpointGetCustomComponentByParentType,
+		EndpointCreateOrUpdateCustomComponent, EndpointDeleteCustomComponent, EndpointDeleteCustomComponentByParentId,
+		EndpointGetAllResourceComponents, EndpointGetResourceComponent, EndpointCreateOrUpdateResourceComponent,
+		EndpointGetAllResourceDelimiters, EndpointGetResourceDelimiter, EndpointCreateOrUpdateResourceDelimiter,
+		EndpointGetAllResourceEnvironments, EndpointGetResourceEnvironment, EndpointCreateOrUpdateResourceEnvironment, EndpointDeleteResourceEnvironment,
+		EndpointGetAllResourceFunctions, EndpointGetResourceFunction, EndpointCreateOrUpdateResourceFunction, EndpointDeleteResourceFunction,
+		EndpointGetAllResourceLocations, EndpointGetResourceLocation, EndpointCreateOrUpdateResourceLocation, EndpointDeleteResourceLocation,
+		EndpointGetAllResourceOrganizations, EndpointGetResourceOrganization, EndpointCreateOrUpdateResourceOrganization, EndpointDeleteResourceOrganization,
+		EndpointGetAllResourceProjects, EndpointGetResourceProject, EndpointCreateOrUpdateResourceProject, EndpointDeleteResourceProject,
+		EndpointGetAllResourceTypes, EndpointGetResourceType, EndpointPostResourceTypesConfig,
+		EndpointGetAllResourceUnits, EndpointGetResourceUnit, EndpointCreateOrUpdateResourceUnit, EndpointDeleteResourceUnit,
+	}
+}
+
+// URL builds the URL of the endpoint below baseURL, escaping every parameter value.
+//
+// Parameters:
+//   - baseURL: The normalised base URL of the Naming Tool, without a trailing slash.
+//   - params: The values of the placeholders of the endpoint path.
+//
+// Returns:
+//   - The URL of the endpoint.
+//   - An error matching utils.ErrInvalidEndpoint if a required parameter is missing
+//     or empty, or a parameter that the endpoint does not declare was given.
+func (e Endpoint) URL(baseURL string, params map[string]string) (string, error) {
+	for _, name := range e.Params {
+		if params[name] == "" {
+			return "", fmt.Errorf("%w: %s requires the %q parameter", utils.ErrInvalidEndpoint, e.Name, name)
+		}
+	}
+	for name := range params {
+		if !e.hasParam(name) {
+			return "", fmt.Errorf("%w: %s has no %q parameter", utils.ErrInvalidEndpoint, e.Name, name)
+		}
+	}
+
+	path := placeholderPattern.ReplaceAllStringFunc(e.Path, func(placeholder string) string {
+		return url.PathEscape(params[placeholder[1:len(placeholder)-1]])
+	})
+	return baseURL + path, nil
+}
+
+// hasParam reports whether the endpoint declares the parameter name.
+func (e Endpoint) hasParam(name string) bool {
+	for _, param := range e.Params {
+		if param == name {
+			return true
+		}
+	}
+	return false
+}
+
+// unserved reports whether resp shows that the Naming Tool does not serve the Optional endpoint.
+func (e Endpoint) unserved(resp *http.Response) bool {
+	return e.Optional && resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+// collection returns the resource collection of the endpoint, the first path segment
+// after /api/, such as /api/ResourceTypes for every resource type endpoint.
+func (e Endpoint) collection() string {
+	segments := strings.SplitN(strings.TrimPrefix(e.Path, "/"), "/", 3)
+	if len(segments) < 2 {
+		return e.Path
+	}
+	return "/" + segments[0] + "/" + segments[1]
+}
+
+// normalizeBaseURL removes the trailing slashes of baseURL so endpoint paths can be
+// appended to it, keeping any path prefix such as /namingtool.
+func normalizeBaseURL(baseURL string) string {
+	return strings.TrimRight(baseURL, "/")
+}
+
+// endpointContextKey is the context key holding the endpoint of a request.
+type endpointContextKey struct{}
+
+// withEndpoint returns a copy of ctx carrying endpoint, so the queue, authenticators
+// and logging can tell which operation a request belongs to.
+func withEndpoint(ctx context.Context, endpoint Endpoint) context.Context {
+	return context.WithValue(ctx, endpointContextKey{}, endpoint)
+}
+
+// EndpointFromContext returns the endpoint of the request that ctx belongs to.
+//
+// Returns:
+//   - The endpoint, and false if the request was not sent through BaseService.
+func EndpointFromContext(ctx context.Context) (Endpoint, bool) {
+	endpoint, ok := ctx.Value(endpointContextKey{}).(Endpoint)
+	return endpoint, ok
+}
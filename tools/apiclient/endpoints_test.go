@@ -0,0 +1,132 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointsDeclareTheirPlaceholders(t *testing.T) {
+	names := map[string]bool{}
+	for _, endpoint := range Endpoints() {
+		assert.False(t, names[endpoint.Name], "duplicate endpoint %s", endpoint.Name)
+		names[endpoint.Name] = true
+
+		assert.ElementsMatch(t, endpoint.Params, pathPlaceholders(endpoint.Path), endpoint.Name)
+		for _, variant := range endpoint.Variants {
+			assert.ElementsMatch(t, endpoint.Params, pathPlaceholders(variant.Path), endpoint.Name)
+		}
+		assert.Contains(t, []string{http.MethodGet, http.MethodPost, http.MethodDelete}, endpoint.Method, endpoint.Name)
+	}
+}
+
+func pathPlaceholders(path string) []string {
+	var placeholders []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(path, -1) {
+		placeholders = append(placeholders, match[1])
+	}
+	return placeholders
+}
+
+func TestEndpointURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint Endpoint
+		params   map[string]string
+		expected string
+		err      error
+	}{
+		{
+			name:     "without parameters",
+			endpoint: EndpointGetAllResourceTypes,
+			expected: "https://example.com/namingtool/api/ResourceTypes",
+		},
+		{
+			name:     "escapes parameter values",
+			endpoint: EndpointGetCustomComponentByParentType,
+			params:   map[string]string{"parentComponentType": "a/b c?d"},
+			expected: "https://example.com/namingtool/api/CustomComponents/GetByParentType/a%2Fb%20c%3Fd",
+		},
+		{
+			name:     "missing parameter",
+			endpoint: EndpointGetResourceType,
+			err:      utils.ErrInvalidEndpoint,
+		},
+		{
+			name:     "empty parameter",
+			endpoint: EndpointGetResourceType,
+			params:   map[string]string{"id": ""},
+			err:      utils.ErrInvalidEndpoint,
+		},
+		{
+			name:     "unknown parameter",
+			endpoint: EndpointGetResourceType,
+			params:   map[string]string{"id": "1", "parentType": "x"},
+			err:      utils.ErrInvalidEndpoint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.endpoint.URL("https://example.com/namingtool", tt.params)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestBaseURLWithPathPrefix(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL+"/namingtool/", server.Client())
+	service := NewBaseService(client)
+
+	var response map[string]interface{}
+	err := service.DoGet(context.Background(), EndpointGetResourceType, map[string]string{"id": "1/2"}, &response)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/namingtool/api/ResourceTypes/1%2F2", path)
+}
+
+func TestDoGetRejectsEndpointMethod(t *testing.T) {
+	client := newTestClient(t, "https://example.com", nil)
+	service := NewBaseService(client)
+
+	err := service.DoGet(context.Background(), EndpointDeleteResourceUnit, map[string]string{"id": "1"}, nil)
+
+	assert.ErrorIs(t, err, utils.ErrInvalidEndpoint)
+}
+
+func TestAdminPasswordOnlySentToAdminEndpoints(t *testing.T) {
+	headers := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers[r.URL.Path] = r.Header.Get("AdminPassword")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, server.Client())
+	service := NewBaseService(client)
+
+	var response map[string]interface{}
+	assert.NoError(t, service.DoGet(context.Background(), EndpointGetGeneratedName, map[string]string{"id": "1"}, &response))
+	assert.NoError(t, service.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &response))
+
+	assert.Equal(t, "123456", headers["/api/Admin/GetGeneratedName/1"])
+	assert.Equal(t, "", headers["/api/ResourceTypes"])
+}
@@ -0,0 +1,318 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// Entity is implemented by the component value models that embed models.ResourceBaseEntity.
+type Entity interface {
+	BaseEntity() models.ResourceBaseEntity
+}
+
+// EntityEndpoints are the endpoints managing one kind of component value.
+type EntityEndpoints struct {
+	Kind           string   // The name of the component value in error messages, such as "environment".
+	List           Endpoint // Returns all entities.
+	Get            Endpoint // Returns the entity with the id parameter.
+	CreateOrUpdate Endpoint // Creates an entity without id, or updates the entity with the id of the body.
+	Delete         Endpoint // Deletes the entity with the id parameter.
+}
+
+var (
+	ResourceEnvironmentEndpoints = EntityEndpoints{
+		Kind:           "environment",
+		List:           EndpointGetAllResourceEnvironments,
+		Get:            EndpointGetResourceEnvironment,
+		CreateOrUpdate: EndpointCreateOrUpdateResourceEnvironment,
+		Delete:         EndpointDeleteResourceEnvironment,
+	}
+	ResourceFunctionEndpoints = EntityEndpoints{
+		Kind:           "function",
+		List:           EndpointGetAllResourceFunctions,
+		Get:            EndpointGetResourceFunction,
+		CreateOrUpdate: EndpointCreateOrUpdateResourceFunction,
+		Delete:         EndpointDeleteResourceFunction,
+	}
+	ResourceLocationEndpoints = EntityEndpoints{
+		Kind:           "location",
+		List:           EndpointGetAllResourceLocations,
+		Get:            EndpointGetResourceLocation,
+		CreateOrUpdate: EndpointCreateOrUpdateResourceLocation,
+		Delete:         EndpointDeleteResourceLocation,
+	}
+	ResourceOrganizationEndpoints = EntityEndpoints{
+		Kind:           "organization",
+		List:           EndpointGetAllResourceOrganizations,
+		Get:            EndpointGetResourceOrganization,
+		CreateOrUpdate: EndpointCreateOrUpdateResourceOrganization,
+		Delete:         EndpointDeleteResourceOrganization,
+	}
+	ResourceProjectEndpoints = EntityEndpoints{
+		Kind:           "project",
+		List:           EndpointGetAllResourceProjects,
+		Get:            EndpointGetResourceProject,
+		CreateOrUpdate: EndpointCreateOrUpdateResourceProject,
+		Delete:         EndpointDeleteResourceProject,
+	}
+	ResourceUnitEndpoints = EntityEndpoints{
+		Kind:           "unit",
+		List:           EndpointGetAllResourceUnits,
+		Get:            EndpointGetResourceUnit,
+		CreateOrUpdate: EndpointCreateOrUpdateResourceUnit,
+		Delete:         EndpointDeleteResourceUnit,
+	}
+)
+
+// EntityService manages one kind of component value of the Naming Tool, such as environments.
+type EntityService[T Entity] struct {
+	baseService *BaseService
+	endpoints   EntityEndpoints
+}
+
+type (
+	ResourceEnvironmentService  = EntityService[models.ResourceEnvironment]
+	ResourceFunctionService     = EntityService[models.ResourceFunction]
+	ResourceLocationService     = EntityService[models.ResourceLocation]
+	ResourceOrganizationService = EntityService[models.ResourceOrganization]
+	ResourceProjectService      = EntityService[models.ResourceProject]
+	ResourceUnitService         = EntityService[models.ResourceUnit]
+)
+
+// NewEntityService creates a new instance of EntityService with the provided API client.
+//
+// Parameters:
+//   - client: A pointer to the APIClient instance.
+//   - endpoints: The endpoints managing the entities of type T.
+//
+// Returns:
+//   - A pointer to the newly created EntityService instance.
+func NewEntityService[T Entity](client *APIClient, endpoints EntityEndpoints) *EntityService[T] {
+	return &EntityService[T]{baseService: NewBaseService(client), endpoints: endpoints}
+}
+
+// NewResourceEnvironmentService creates the service managing resource environments.
+func NewResourceEnvironmentService(client *APIClient) *ResourceEnvironmentService {
+	return NewEntityService[models.ResourceEnvironment](client, ResourceEnvironmentEndpoints)
+}
+
+// NewResourceFunctionService creates the service managing resource functions.
+func NewResourceFunctionService(client *APIClient) *ResourceFunctionService {
+	return NewEntityService[models.ResourceFunction](client, ResourceFunctionEndpoints)
+}
+
+// NewResourceLocationService creates the service managing resource locations.
+func NewResourceLocationService(client *APIClient) *ResourceLocationService {
+	return NewEntityService[models.ResourceLocation](client, ResourceLocationEndpoints)
+}
+
+// NewResourceOrganizationService creates the service managing resource organizations.
+func NewResourceOrganizationService(client *APIClient) *ResourceOrganizationService {
+	return NewEntityService[models.ResourceOrganization](client, ResourceOrganizationEndpoints)
+}
+
+// NewResourceProjectService creates the service managing resource projects, applications and services.
+func NewResourceProjectService(client *APIClient) *ResourceProjectService {
+	return NewEntityService[models.ResourceProject](client, ResourceProjectEndpoints)
+}
+
+// NewResourceUnitService creates the service managing resource units and departments.
+func NewResourceUnitService(client *APIClient) *ResourceUnitService {
+	return NewEntityService[models.ResourceUnit](client, ResourceUnitEndpoints)
+}
+
+// List retrieves all entities.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//
+// Returns:
+//   - A slice containing the entities.
+//   - An error if the request fails.
+func (s *EntityService[T]) List(ctx context.Context) ([]T, error) {
+	var response []T
+	if err := s.baseService.DoGet(ctx, s.endpoints.List, nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get retrieves the entity with the provided ID.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - id: The ID of the entity.
+//
+// Returns:
+//   - A pointer to the entity.
+//   - An error matching utils.ErrNotFound if the entity does not exist, or an error if the request fails.
+func (s *EntityService[T]) Get(ctx context.Context, id int64) (*T, error) {
+	var response T
+	if err := s.baseService.DoGet(ctx, s.endpoints.Get, idParams(id), &response); err != nil {
+		return nil, err
+	}
+	if response.BaseEntity().Id == 0 {
+		return nil, fmt.Errorf("%w: %s %d", utils.ErrNotFound, s.endpoints.Kind, id)
+	}
+	return &response, nil
+}
+
+// Create adds a new entity. The Naming Tool assigns its ID, and does not reject duplicate
+// short names, so the entity is only created if no entity has its short name. When the
+// Naming Tool does not answer with the new entity, it is looked up among the entities that
+// did not exist before the request.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - entity: The entity to create, without ID.
+//
+// Returns:
+//   - A pointer to the created entity.
+//   - An error matching utils.ErrConflict if an entity has the short name, an error
+//     matching utils.ErrNotFound if the created entity cannot be identified, or an error
+//     if the entity has an ID or a request fails.
+func (s *EntityService[T]) Create(ctx context.Context, entity T) (*T, error) {
+	requested := entity.BaseEntity()
+	if requested.Id != 0 {
+		return nil, fmt.Errorf("cannot create %s with ID %d, use Update to change an existing %s", s.endpoints.Kind, requested.Id, s.endpoints.Kind)
+	}
+
+	existing, err := s.listUncached(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int64]bool, len(existing))
+	for i := range existing {
+		current := existing[i].BaseEntity()
+		known[current.Id] = true
+		if requested.ShortName != "" && strings.EqualFold(current.ShortName, requested.ShortName) {
+			return nil, fmt.Errorf("%w: the %s %q (ID %d) already uses the short name %q", utils.ErrConflict, s.endpoints.Kind, current.Name, current.Id, current.ShortName)
+		}
+	}
+
+	created, err := s.createOrUpdate(ctx, entity)
+	if err != nil || (*created).BaseEntity().Id != 0 {
+		return created, err
+	}
+
+	entities, err := s.listUncached(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var match *T
+	for i := range entities {
+		current := entities[i].BaseEntity()
+		if known[current.Id] || !strings.EqualFold(current.Name, requested.Name) || !strings.EqualFold(current.ShortName, requested.ShortName) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("%w: more than one new %s has the name %q and short name %q", utils.ErrConflict, s.endpoints.Kind, requested.Name, requested.ShortName)
+		}
+		match = &entities[i]
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: the created %s %q is not listed by the Naming Tool", utils.ErrNotFound, s.endpoints.Kind, requested.Name)
+	}
+	return match, nil
+}
+
+// Update changes the existing entity with the ID of entity.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - entity: The entity to update, with its ID.
+//
+// Returns:
+//   - A pointer to the entity returned by the Naming Tool, without ID if the Naming Tool
+//     did not answer with the entity.
+//   - An error matching utils.ErrNotFound if the entity does not exist, or an error if
+//     the entity has no ID or the request fails.
+func (s *EntityService[T]) Update(ctx context.Context, entity T) (*T, error) {
+	id := entity.BaseEntity().Id
+	if id == 0 {
+		return nil, fmt.Errorf("cannot update %s without ID, use Create to add a %s", s.endpoints.Kind, s.endpoints.Kind)
+	}
+	// The Naming Tool creates the entity if the ID does not exist, so check it first
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.createOrUpdate(ctx, entity)
+}
+
+// Delete deletes the entity with the provided ID.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - id: The ID of the entity.
+//
+// Returns:
+//   - An error if the request fails.
+func (s *EntityService[T]) Delete(ctx context.Context, id int64) error {
+	return s.baseService.DoDelete(ctx, s.endpoints.Delete, idParams(id))
+}
+
+// FindByName returns the entity whose name matches name, ignoring case.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - name: The name of the entity.
+//
+// Returns:
+//   - A pointer to the entity.
+//   - An error matching utils.ErrNotFound if no entity has the name, or an error if the request fails.
+func (s *EntityService[T]) FindByName(ctx context.Context, name string) (*T, error) {
+	return s.find(ctx, "name", name, func(entity models.ResourceBaseEntity) string { return entity.Name })
+}
+
+// FindByShortName returns the entity whose short name matches shortName, ignoring case.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - shortName: The short name of the entity, as used in generated names.
+//
+// Returns:
+//   - A pointer to the entity.
+//   - An error matching utils.ErrNotFound if no entity has the short name, or an error if the request fails.
+func (s *EntityService[T]) FindByShortName(ctx context.Context, shortName string) (*T, error) {
+	return s.find(ctx, "short name", shortName, func(entity models.ResourceBaseEntity) string { return entity.ShortName })
+}
+
+// find returns the first entity whose field, read by value, matches expected ignoring case.
+func (s *EntityService[T]) find(ctx context.Context, field string, expected string, value func(models.ResourceBaseEntity) string) (*T, error) {
+	entities, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entities {
+		if strings.EqualFold(value(entities[i].BaseEntity()), expected) {
+			return &entities[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s with %s %q", utils.ErrNotFound, s.endpoints.Kind, field, expected)
+}
+
+// listUncached retrieves all entities from the Naming Tool, bypassing the cache.
+func (s *EntityService[T]) listUncached(ctx context.Context) ([]T, error) {
+	endpoint := s.endpoints.List
+	endpoint.Cacheable = false
+	var response []T
+	if err := s.baseService.DoGet(ctx, endpoint, nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// createOrUpdate posts entity to the CreateOrUpdate endpoint.
+func (s *EntityService[T]) createOrUpdate(ctx context.Context, entity T) (*T, error) {
+	return postWrite[T](ctx, s.baseService, s.endpoints.CreateOrUpdate, entity)
+}
+
+// idParams returns the path parameters of the endpoints addressing an entity by ID.
+func idParams(id int64) map[string]string {
+	return map[string]string{"id": strconv.FormatInt(id, 10)}
+}
@@ -0,0 +1,126 @@
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// entityServiceCase runs the test suite against the service of one entity type.
+type entityServiceCase struct {
+	name string
+	run  func(t *testing.T, client *APIClient)
+}
+
+func newEntityServiceCase[T Entity](name string, newService func(*APIClient) *EntityService[T], newEntity func(models.ResourceBaseEntity) T) entityServiceCase {
+	return entityServiceCase{
+		name: name,
+		run: func(t *testing.T, client *APIClient) {
+			ctx := context.Background()
+			service := newService(client)
+
+			list, err := service.List(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, list, 2)
+
+			entity, err := service.Get(ctx, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, "Production", (*entity).BaseEntity().Name)
+
+			_, err = service.Get(ctx, 42)
+			assert.ErrorIs(t, err, utils.ErrNotFound)
+
+			entity, err = service.FindByName(ctx, "development")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), (*entity).BaseEntity().Id)
+
+			entity, err = service.FindByShortName(ctx, "PRD")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), (*entity).BaseEntity().Id)
+
+			_, err = service.FindByName(ctx, "Staging")
+			assert.ErrorIs(t, err, utils.ErrNotFound)
+
+			created, err := service.Create(ctx, newEntity(models.ResourceBaseEntity{Name: "Staging", ShortName: "stg"}))
+			assert.NoError(t, err)
+			assert.Equal(t, int64(3), (*created).BaseEntity().Id)
+
+			_, err = service.Create(ctx, newEntity(models.ResourceBaseEntity{Id: 3, Name: "Staging"}))
+			assert.ErrorContains(t, err, "use Update")
+
+			_, err = service.Create(ctx, newEntity(models.ResourceBaseEntity{Name: "Testing", ShortName: "STG"}))
+			assert.ErrorIs(t, err, utils.ErrConflict)
+
+			updated, err := service.Update(ctx, newEntity(models.ResourceBaseEntity{Id: 3, Name: "Staging", ShortName: "stage"}))
+			assert.NoError(t, err)
+			assert.Equal(t, "stage", (*updated).BaseEntity().ShortName)
+
+			entity, err = service.FindByShortName(ctx, "stage")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(3), (*entity).BaseEntity().Id)
+
+			_, err = service.Update(ctx, newEntity(models.ResourceBaseEntity{Id: 42, Name: "Missing"}))
+			assert.ErrorIs(t, err, utils.ErrNotFound)
+
+			_, err = service.Update(ctx, newEntity(models.ResourceBaseEntity{Name: "Missing"}))
+			assert.ErrorContains(t, err, "use Create")
+
+			assert.NoError(t, service.Delete(ctx, 3))
+			assert.ErrorIs(t, service.Delete(ctx, 3), utils.ErrNotFound)
+
+			list, err = service.List(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, list, 2)
+		},
+	}
+}
+
+func TestEntityServices(t *testing.T) {
+	tests := []struct {
+		endpoints EntityEndpoints
+		test      entityServiceCase
+	}{
+		{ResourceEnvironmentEndpoints, newEntityServiceCase("environments", NewResourceEnvironmentService,
+			func(base models.ResourceBaseEntity) models.ResourceEnvironment {
+				return models.ResourceEnvironment{ResourceBaseEntity: base}
+			})},
+		{ResourceFunctionEndpoints, newEntityServiceCase("functions", NewResourceFunctionService,
+			func(base models.ResourceBaseEntity) models.ResourceFunction {
+				return models.ResourceFunction{ResourceBaseEntity: base}
+			})},
+		{ResourceLocationEndpoints, newEntityServiceCase("locations", NewResourceLocationService,
+			func(base models.ResourceBaseEntity) models.ResourceLocation {
+				return models.ResourceLocation{ResourceBaseEntity: base}
+			})},
+		{ResourceOrganizationEndpoints, newEntityServiceCase("organizations", NewResourceOrganizationService,
+			func(base models.ResourceBaseEntity) models.ResourceOrganization {
+				return models.ResourceOrganization{ResourceBaseEntity: base}
+			})},
+		{ResourceProjectEndpoints, newEntityServiceCase("projects", NewResourceProjectService,
+			func(base models.ResourceBaseEntity) models.ResourceProject {
+				return models.ResourceProject{ResourceBaseEntity: base}
+			})},
+		{ResourceUnitEndpoints, newEntityServiceCase("units", NewResourceUnitService,
+			func(base models.ResourceBaseEntity) models.ResourceUnit {
+				return models.ResourceUnit{ResourceBaseEntity: base}
+			})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.test.name, func(t *testing.T) {
+			server := namingtooltest.NewServer(t)
+			server.Put(tt.endpoints.List.Path,
+				models.ResourceBaseEntity{Id: 1, Name: "Development", ShortName: "dev", SortOrder: 1},
+				models.ResourceBaseEntity{Id: 2, Name: "Production", ShortName: "prd", SortOrder: 2},
+			)
+
+			// Run with the cache to check that writes are visible to later reads
+			client := newTestClient(t, server.URL, server.Client(), WithCache(DefaultCacheTTL))
+			tt.test.run(t, client)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// maxErrorBodySize limits how much of an error response body is kept in an APIError.
+const maxErrorBodySize = 64 * 1024
+
+// APIError describes a request that the Naming Tool answered with an error status code.
+// It wraps the sentinel error matching the status code, so callers can use
+// errors.Is(err, utils.ErrNotFound) as well as errors.As(err, &apiErr).
+type APIError struct {
+	Method     string // The HTTP method of the request.
+	Endpoint   string // The key of the endpoint in the client's endpoint map.
+	URL        string // The URL of the request.
+	StatusCode int    // The status code of the response.
+	Message    string // The message decoded from the response body, if any.
+	Body       string // The raw response body, truncated to 64 KiB.
+	RequestID  string // The request ID reported by the server, if any.
+}
+
+// Error returns a description of the failed request including the server message.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s (%s) returned %d %s", e.Method, e.Endpoint, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error matching the status code, or nil if there is none.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return utils.ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden:
+		return utils.ErrUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return utils.ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return utils.ErrConflict
+	case e.StatusCode == http.StatusTooManyRequests:
+		return utils.ErrTooManyRequests
+	case e.StatusCode >= http.StatusInternalServerError:
+		return utils.ErrServerError
+	}
+	return nil
+}
+
+// newAPIError builds an APIError from an error response, consuming its body.
+//
+// Parameters:
+//   - endpointKey: The key of the endpoint that was called.
+//   - resp: The response with an error status code.
+//
+// Returns:
+//   - A pointer to the APIError describing the response.
+func newAPIError(endpointKey string, resp *http.Response) *APIError {
+	apiErr := &APIError{
+		Endpoint:   endpointKey,
+		StatusCode: resp.StatusCode,
+		RequestID:  requestID(resp.Header),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	apiErr.Body = string(body)
+	apiErr.Message = decodeErrorMessage(body)
+
+	return apiErr
+}
+
+// requestID returns the request correlation ID set by the Naming Tool or App Service.
+func requestID(header http.Header) string {
+	for _, name := range []string{"X-Request-Id", "Request-Id", "X-Ms-Request-Id", "X-Correlation-Id"} {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// decodeErrorMessage extracts a human readable message from an error response body.
+// The Naming Tool answers with a JSON string, an object with a message or
+// ASP.NET problem details, depending on the endpoint and the failure.
+func decodeErrorMessage(body []byte) string {
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(body, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err == nil {
+		fields := make(map[string]string, len(object))
+		for key, value := range object {
+			if text, ok := value.(string); ok {
+				fields[strings.ToLower(key)] = text
+			}
+		}
+		for _, key := range []string{"message", "detail", "error", "title"} {
+			if text := fields[key]; text != "" {
+				return text
+			}
+		}
+		return ""
+	}
+
+	return trimmed
+}
@@ -0,0 +1,58 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		sentinel error
+		message  string
+	}{
+		{"json message", http.StatusNotFound, `{"message": "Generated name not found"}`, utils.ErrNotFound, "Generated name not found"},
+		{"problem details", http.StatusBadRequest, `{"title": "One or more validation errors occurred.", "status": 400}`, utils.ErrBadRequest, "One or more validation errors occurred."},
+		{"json string", http.StatusUnauthorized, `"Api Key not valid!"`, utils.ErrUnauthorized, "Api Key not valid!"},
+		{"plain text", http.StatusConflict, "Name already exists", utils.ErrConflict, "Name already exists"},
+		{"empty body", http.StatusInternalServerError, "", utils.ErrServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req-42")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server.URL, server.Client())
+			client.RetryPolicy.MaxAttempts = 1
+			service := NewBaseService(client)
+
+			var response models.ResourceGeneratedName
+			err := service.DoGet(context.Background(), EndpointGetGeneratedName, map[string]string{"id": "1"}, &response)
+
+			var apiErr *APIError
+			if assert.True(t, errors.As(err, &apiErr)) {
+				assert.Equal(t, http.MethodGet, apiErr.Method)
+				assert.Equal(t, "GetGeneratedName", apiErr.Endpoint)
+				assert.Equal(t, server.URL+"/api/Admin/GetGeneratedName/1", apiErr.URL)
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+				assert.Equal(t, tt.message, apiErr.Message)
+				assert.Equal(t, "req-42", apiErr.RequestID)
+			}
+			assert.ErrorIs(t, err, tt.sentinel)
+		})
+	}
+}
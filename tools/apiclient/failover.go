@@ -0,0 +1,220 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultHealthCheckInterval is how long an instance that failed is skipped before it is probed again.
+	DefaultHealthCheckInterval = 30 * time.Second
+	// healthCheckTimeout bounds a single health probe.
+	healthCheckTimeout = 5 * time.Second
+)
+
+// instance is the health of one Naming Tool instance the client can send requests to.
+type instance struct {
+	baseURL string
+	healthy bool
+	checked time.Time // When the health was last probed or changed
+}
+
+// InstanceHealth is the result of probing a Naming Tool instance.
+type InstanceHealth struct {
+	BaseURL string // The base URL of the instance.
+	Healthy bool   // Whether the instance answered the probe without a server error.
+	Err     error  // Why the instance is unhealthy, or nil.
+}
+
+// WithFailoverURLs adds standby Naming Tool instances sharing the storage of the instance
+// at BaseURL. Requests go to the first healthy instance in the order BaseURL, then urls.
+func WithFailoverURLs(urls ...string) ClientOption {
+	return func(c *APIClient) {
+		c.FailoverURLs = append([]string(nil), urls...)
+	}
+}
+
+// WithHealthCheckInterval sets how long an instance that failed is skipped before it is probed again.
+func WithHealthCheckInterval(interval time.Duration) ClientOption {
+	return func(c *APIClient) {
+		c.HealthCheckInterval = interval
+	}
+}
+
+// CheckHealth probes every instance of the client and routes later requests to the first
+// healthy one. An instance is healthy when it answers the probe with a status below 500.
+//
+// Parameters:
+//   - ctx: The context used to cancel the probes.
+//
+// Returns:
+//   - The health of every instance, in the order requests try them.
+func (c *APIClient) CheckHealth(ctx context.Context) []InstanceHealth {
+	results := make([]InstanceHealth, len(c.instances))
+	var wg sync.WaitGroup
+	for i, inst := range c.instances {
+		wg.Add(1)
+		go func(i int, inst *instance) {
+			defer wg.Done()
+			err := c.probe(ctx, inst.baseURL)
+			c.setHealth(ctx, inst, err == nil, err)
+			results[i] = InstanceHealth{BaseURL: inst.baseURL, Healthy: err == nil, Err: err}
+		}(i, inst)
+	}
+	wg.Wait()
+	return results
+}
+
+// instanceOrder returns the base URLs of the instances in the order a request tries them:
+// the healthy instances first, then the unhealthy ones, each in configuration order.
+// Unhealthy instances not probed within HealthCheckInterval are probed first.
+func (c *APIClient) instanceOrder(ctx context.Context) []string {
+	if len(c.instances) == 1 {
+		return []string{c.instances[0].baseURL}
+	}
+
+	c.healthMu.Lock()
+	var due []*instance
+	for _, inst := range c.instances {
+		if !inst.healthy && time.Since(inst.checked) >= c.HealthCheckInterval {
+			// Claim the probe so that concurrent requests do not probe the same instance
+			inst.checked = time.Now()
+			due = append(due, inst)
+		}
+	}
+	c.healthMu.Unlock()
+
+	for _, inst := range due {
+		err := c.probe(ctx, inst.baseURL)
+		c.setHealth(ctx, inst, err == nil, err)
+	}
+
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+	healthy := make([]string, 0, len(c.instances))
+	var unhealthy []string
+	for _, inst := range c.instances {
+		if inst.healthy {
+			healthy = append(healthy, inst.baseURL)
+		} else {
+			unhealthy = append(unhealthy, inst.baseURL)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// markUnhealthy records that the instance at baseURL failed a request.
+func (c *APIClient) markUnhealthy(ctx context.Context, baseURL string, cause error) {
+	for _, inst := range c.instances {
+		if inst.baseURL == baseURL {
+			c.setHealth(ctx, inst, false, cause)
+		}
+	}
+}
+
+// setHealth updates the health of inst, logging when it changes.
+func (c *APIClient) setHealth(ctx context.Context, inst *instance, healthy bool, cause error) {
+	c.healthMu.Lock()
+	changed := inst.healthy != healthy
+	inst.healthy = healthy
+	inst.checked = time.Now()
+	c.healthMu.Unlock()
+
+	switch {
+	case changed && healthy:
+		tflog.Info(ctx, "Naming Tool instance is healthy again", map[string]interface{}{"base_url": inst.baseURL})
+	case changed:
+		tflog.Warn(ctx, "Naming Tool instance is unhealthy, failing over", map[string]interface{}{"base_url": inst.baseURL, "error": cause.Error()})
+	}
+}
+
+// probe sends an unauthenticated GET to the version endpoint of the instance at baseURL.
+// Any response below 500 shows the instance is serving requests, even if it requires
+// credentials or predates the version endpoint.
+func (c *APIClient) probe(ctx context.Context, baseURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+EndpointGetServerVersion.Path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("health probe returned %s", resp.Status)
+	}
+	return nil
+}
+
+// shouldFailover reports whether a request that produced resp or err may be sent to the
+// next instance. Like retries, requests that are not idempotent only fail over when the
+// instance cannot have processed them, since another instance could mint a second name.
+func shouldFailover(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return isIdempotent(method) || isDialError(err)
+	}
+	return resp.StatusCode >= 500 && isIdempotent(method)
+}
+
+// failoverCause describes why an instance failed a request, for the logs.
+func failoverCause(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("request returned %s", resp.Status)
+}
+
+// rebaseURL moves endpointURL, built below the client's BaseURL, to the instance at baseURL.
+func (c *APIClient) rebaseURL(endpointURL string, baseURL string) string {
+	return baseURL + strings.TrimPrefix(endpointURL, c.BaseURL)
+}
+
+// instanceContextKey is the context key of the instanceTracker.
+type instanceContextKey struct{}
+
+// instanceTracker records the instance that answered the requests of a context.
+type instanceTracker struct {
+	mu      sync.Mutex
+	baseURL string
+}
+
+// TrackInstance returns a context recording which Naming Tool instance answers the
+// requests sent with it, such as a name request after a failover.
+//
+// Returns:
+//   - The context to send the requests with.
+//   - A function returning the base URL of the instance that answered the last request,
+//     or an empty string if no instance answered.
+func TrackInstance(ctx context.Context) (context.Context, func() string) {
+	tracker := &instanceTracker{}
+	return context.WithValue(ctx, instanceContextKey{}, tracker), func() string {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return tracker.baseURL
+	}
+}
+
+// recordInstance stores baseURL in the instance tracker of ctx, if any.
+func recordInstance(ctx context.Context, baseURL string) {
+	if tracker, ok := ctx.Value(instanceContextKey{}).(*instanceTracker); ok {
+		tracker.mu.Lock()
+		tracker.baseURL = baseURL
+		tracker.mu.Unlock()
+	}
+}
@@ -0,0 +1,127 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// instanceServer is a Naming Tool instance answering with status, counting the requests
+// other than health probes.
+type instanceServer struct {
+	*httptest.Server
+	status   atomic.Int32
+	requests atomic.Int32
+	probes   atomic.Int32
+}
+
+func newInstanceServer(t *testing.T, status int) *instanceServer {
+	s := &instanceServer{}
+	s.status.Store(int32(status))
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EndpointGetServerVersion.Path {
+			s.probes.Add(1)
+		} else {
+			s.requests.Add(1)
+		}
+		w.WriteHeader(int(s.status.Load()))
+		w.Write([]byte(`{"id": 7}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newFailoverClient(t *testing.T, primary string, standby string, opts ...ClientOption) *APIClient {
+	client := newTestClient(t, primary, nil, append([]ClientOption{WithFailoverURLs(standby + "/")}, opts...)...)
+	client.RetryPolicy.MaxAttempts = 1
+	return client
+}
+
+func TestFailoverOnServerError(t *testing.T) {
+	primary := newInstanceServer(t, http.StatusInternalServerError)
+	standby := newInstanceServer(t, http.StatusOK)
+	client := newFailoverClient(t, primary.URL, standby.URL, WithHealthCheckInterval(time.Hour))
+	service := NewBaseService(client)
+
+	ctx, servedBy := TrackInstance(context.Background())
+	var response map[string]interface{}
+	assert.NoError(t, service.DoGet(ctx, EndpointGetResourceType, idParams(7), &response))
+	assert.Equal(t, standby.URL, servedBy())
+	assert.Equal(t, int32(1), primary.requests.Load())
+
+	// The primary is skipped until the health check interval has passed
+	assert.NoError(t, service.DoGet(ctx, EndpointGetResourceType, idParams(7), &response))
+	assert.Equal(t, int32(1), primary.requests.Load())
+	assert.Equal(t, int32(2), standby.requests.Load())
+}
+
+func TestFailoverOnConnectionError(t *testing.T) {
+	primary := newInstanceServer(t, http.StatusOK)
+	primary.Close()
+	standby := newInstanceServer(t, http.StatusOK)
+	client := newFailoverClient(t, primary.URL, standby.URL)
+
+	// Name requests fail over when the primary cannot have received them
+	ctx, servedBy := TrackInstance(context.Background())
+	var response models.ResourceNameResponse
+	assert.NoError(t, NewBaseService(client).DoPost(ctx, EndpointRequestName, models.ResourceNameRequest{}, &response))
+	assert.Equal(t, standby.URL, servedBy())
+}
+
+func TestNoFailoverOfNameRequestOnServerError(t *testing.T) {
+	primary := newInstanceServer(t, http.StatusInternalServerError)
+	standby := newInstanceServer(t, http.StatusOK)
+	client := newFailoverClient(t, primary.URL, standby.URL)
+
+	// The primary may have generated the name, so the standby must not generate another one
+	var response models.ResourceNameResponse
+	err := NewBaseService(client).DoPost(context.Background(), EndpointRequestName, models.ResourceNameRequest{}, &response)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), primary.requests.Load())
+	assert.Equal(t, int32(0), standby.requests.Load())
+}
+
+func TestFailbackAfterHealthCheck(t *testing.T) {
+	primary := newInstanceServer(t, http.StatusBadGateway)
+	standby := newInstanceServer(t, http.StatusOK)
+	client := newFailoverClient(t, primary.URL, standby.URL, WithHealthCheckInterval(time.Millisecond))
+	service := NewBaseService(client)
+
+	var response map[string]interface{}
+	assert.NoError(t, service.DoGet(context.Background(), EndpointGetResourceType, idParams(7), &response))
+
+	primary.status.Store(http.StatusOK)
+	time.Sleep(5 * time.Millisecond)
+
+	ctx, servedBy := TrackInstance(context.Background())
+	assert.NoError(t, service.DoGet(ctx, EndpointGetResourceType, idParams(7), &response))
+	assert.Equal(t, primary.URL, servedBy())
+	assert.Equal(t, int32(1), primary.probes.Load())
+}
+
+func TestCheckHealth(t *testing.T) {
+	primary := newInstanceServer(t, http.StatusServiceUnavailable)
+	standby := newInstanceServer(t, http.StatusUnauthorized)
+	client := newFailoverClient(t, primary.URL, standby.URL, WithHealthCheckInterval(time.Hour))
+
+	health := client.CheckHealth(context.Background())
+	if assert.Len(t, health, 2) {
+		assert.Equal(t, primary.URL, health[0].BaseURL)
+		assert.False(t, health[0].Healthy)
+		assert.ErrorContains(t, health[0].Err, "503")
+		assert.Equal(t, standby.URL, health[1].BaseURL)
+		assert.True(t, health[1].Healthy)
+	}
+	assert.Equal(t, []string{standby.URL, primary.URL}, client.instanceOrder(context.Background()))
+}
+
+func TestInvalidFailoverURL(t *testing.T) {
+	_, err := NewAPIClient("https://primary.example.com", "key", "", nil, WithFailoverURLs("ftp://standby"))
+	assert.ErrorContains(t, err, "must start with http")
+}
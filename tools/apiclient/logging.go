@@ -0,0 +1,133 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// logSubsystem is the tflog subsystem used for HTTP traffic. Its level is read from
+	// the TF_LOG_PROVIDER_AZNAMINGTOOL_API environment variable.
+	logSubsystem = "api"
+	// logLevelEnvPrefix is joined with logSubsystem to build the environment variable name.
+	logLevelEnvPrefix = "TF_LOG_PROVIDER_AZNAMINGTOOL"
+	// maxLoggedBodySize limits how much of a body is written to the logs.
+	maxLoggedBodySize = 64 * 1024
+)
+
+// sensitiveHeaders are logged with a masked value.
+var sensitiveHeaders = []string{"Apikey", "Adminpassword", "Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveBodyFields matches JSON properties whose values are masked in logged bodies.
+var sensitiveBodyFields = regexp.MustCompile(`(?i)("(?:apikey|adminpassword|password|client_secret|access_token|refresh_token|token)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// WithBodyLogging enables logging request and response bodies in the api log subsystem.
+// Secrets in the bodies are masked, but bodies may still contain data that should not
+// be shared, so it is disabled by default.
+func WithBodyLogging(enabled bool) ClientOption {
+	return func(c *APIClient) {
+		c.LogBodies = enabled
+	}
+}
+
+// logContext returns ctx with the api log subsystem, masking the client's credentials.
+func (c *APIClient) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv(logLevelEnvPrefix, logSubsystem))
+
+	keys := make([]string, 0, len(sensitiveHeaders)*2)
+	for _, header := range sensitiveHeaders {
+		keys = append(keys, "http.request.header."+strings.ToLower(header), "http.response.header."+strings.ToLower(header))
+	}
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, keys...)
+
+	secrets := make([]string, 0, 2)
+	for _, secret := range []string{c.APIKey, c.AdminPassword} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, secrets...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, secrets...)
+	}
+	return ctx
+}
+
+// logRequest logs an outgoing request in the api log subsystem.
+func (c *APIClient) logRequest(ctx context.Context, req *http.Request) {
+	fields := map[string]interface{}{
+		"http.request.method":    req.Method,
+		"http.request.url":       req.URL.String(),
+		"http.request.body_size": req.ContentLength,
+	}
+	if endpoint, ok := EndpointFromContext(ctx); ok {
+		fields["naming_tool.endpoint"] = endpoint.Name
+	}
+	addHeaderFields(fields, "http.request.header.", req.Header)
+
+	if c.LogBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			content, _ := io.ReadAll(io.LimitReader(body, maxLoggedBodySize))
+			body.Close()
+			fields["http.request.body"] = redactBody(content)
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending Naming Tool request", fields)
+}
+
+// logResponse logs the outcome of a request in the api log subsystem. When body logging
+// is enabled, the logged part of the body is buffered and replayed to the caller.
+func (c *APIClient) logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	fields := map[string]interface{}{
+		"http.request.method": req.Method,
+		"http.request.url":    req.URL.String(),
+		"http.duration_ms":    duration.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemWarn(ctx, logSubsystem, "Naming Tool request failed", fields)
+		return
+	}
+
+	fields["http.response.status_code"] = resp.StatusCode
+	fields["http.response.body_size"] = resp.ContentLength
+	addHeaderFields(fields, "http.response.header.", resp.Header)
+
+	if c.LogBodies {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodySize))
+		resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(content), resp.Body), Closer: resp.Body}
+		fields["http.response.body"] = redactBody(content)
+		if resp.ContentLength < 0 {
+			fields["http.response.body_size"] = len(content)
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received Naming Tool response", fields)
+}
+
+// addHeaderFields adds one log field per header, using the lower case header name after prefix.
+func addHeaderFields(fields map[string]interface{}, prefix string, header http.Header) {
+	for name, values := range header {
+		fields[prefix+strings.ToLower(name)] = strings.Join(values, ", ")
+	}
+}
+
+// redactBody masks the values of JSON properties that hold secrets.
+func redactBody(body []byte) string {
+	return sensitiveBodyFields.ReplaceAllString(string(body), `${1}"***"`)
+}
+
+// readCloser combines a reader replaying a buffered body with the closer of the original body.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
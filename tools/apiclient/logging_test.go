@@ -0,0 +1,51 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLoggingMasksSecrets(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_AZNAMINGTOOL_API", "DEBUG")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"Id": 1, "Name": "unit", "Password": "hunter2"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := newTestClient(t, server.URL, server.Client(), WithBodyLogging(true))
+	service := NewBaseService(client)
+
+	var response models.ResourceUnit
+	err := service.DoPost(ctx, EndpointCreateOrUpdateResourceUnit, map[string]string{"Name": "unit", "Note": "key 123456"}, &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "unit", response.Name)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	sent, received := entries[0], entries[1]
+	assert.Equal(t, "Sending Naming Tool request", sent["@message"])
+	assert.Equal(t, "POST", sent["http.request.method"])
+	assert.Equal(t, "***", sent["http.request.header.apikey"])
+	assert.NotContains(t, sent, "http.request.header.adminpassword")
+	assert.Equal(t, "CreateOrUpdateResourceUnit", sent["naming_tool.endpoint"])
+	assert.Equal(t, `{"Name":"unit","Note":"key ***"}`, sent["http.request.body"])
+
+	assert.Equal(t, "Received Naming Tool response", received["@message"])
+	assert.Equal(t, float64(http.StatusOK), received["http.response.status_code"])
+	assert.Equal(t, `{"Id": 1, "Name": "unit", "Password": "***"}`, received["http.response.body"])
+	assert.Contains(t, received, "http.duration_ms")
+}
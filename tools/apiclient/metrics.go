@@ -0,0 +1,225 @@
+package apiclient
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricsPrefix is the prefix of every metric name.
+const metricsPrefix = "aznamingtool_"
+
+// latencyBuckets are the upper bounds, in seconds, of the request duration and queue wait histograms.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics counts the requests sent by a client per endpoint, with their latency and the
+// time they waited in the queues. It is safe for concurrent use.
+type Metrics struct {
+	mu         sync.Mutex
+	requests   map[requestKey]uint64 // Requests by endpoint and status
+	errors     map[string]uint64     // Failed requests by endpoint
+	durations  map[string]*histogram // Request durations by endpoint
+	queueWaits map[string]*histogram // Queue waits by queue
+
+	fileMu sync.Mutex // Serializes WriteFile so the newest snapshot is written last
+}
+
+type requestKey struct {
+	endpoint string
+	status   string
+}
+
+type histogram struct {
+	counts []uint64 // Observations per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// NewMetrics creates empty metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests:   map[requestKey]uint64{},
+		errors:     map[string]uint64{},
+		durations:  map[string]*histogram{},
+		queueWaits: map[string]*histogram{},
+	}
+}
+
+// WithMetricsFile writes the client metrics in OpenMetrics text format to path when
+// FlushMetrics is called after requests completed, and when the client is closed.
+func WithMetricsFile(path string) ClientOption {
+	return func(c *APIClient) {
+		c.MetricsFile = path
+	}
+}
+
+// observeRequest records one attempt of a request to endpoint. The status is the HTTP
+// status code, or "error" when no response was received.
+func (m *Metrics) observeRequest(endpoint string, statusCode int, err error, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	status := strconv.Itoa(statusCode)
+	if err != nil {
+		status = "error"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{endpoint: endpoint, status: status}]++
+	if err != nil || statusCode >= 400 {
+		m.errors[endpoint]++
+	}
+	observe(m.durations, endpoint, duration)
+}
+
+// observeQueueWait records how long a request waited in queue before it was sent.
+func (m *Metrics) observeQueueWait(queue string, wait time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	observe(m.queueWaits, queue, wait)
+}
+
+// observe adds duration to the histogram of key, creating it if needed.
+func observe(histograms map[string]*histogram, key string, duration time.Duration) {
+	h, ok := histograms[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(latencyBuckets))}
+		histograms[key] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// WriteOpenMetrics writes the metrics in the OpenMetrics text format.
+//
+// Parameters:
+//   - w: The writer receiving the metrics.
+//
+// Returns:
+//   - An error if writing fails.
+func (m *Metrics) WriteOpenMetrics(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "# TYPE %srequests counter\n", metricsPrefix)
+	fmt.Fprintf(out, "# HELP %srequests Requests sent to the Naming Tool, including retries.\n", metricsPrefix)
+	keys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, key := range keys {
+		fmt.Fprintf(out, "%srequests_total{endpoint=%s,status=%s} %d\n", metricsPrefix, quoteLabel(key.endpoint), quoteLabel(key.status), m.requests[key])
+	}
+
+	fmt.Fprintf(out, "# TYPE %srequest_errors counter\n", metricsPrefix)
+	fmt.Fprintf(out, "# HELP %srequest_errors Requests that failed or returned an error status.\n", metricsPrefix)
+	for _, endpoint := range sortedKeys(m.errors) {
+		fmt.Fprintf(out, "%srequest_errors_total{endpoint=%s} %d\n", metricsPrefix, quoteLabel(endpoint), m.errors[endpoint])
+	}
+
+	writeHistograms(out, "request_duration_seconds", "Duration of the requests sent to the Naming Tool.", "endpoint", m.durations)
+	writeHistograms(out, "queue_wait_seconds", "Time requests waited in the client queues before they were sent.", "queue", m.queueWaits)
+
+	fmt.Fprintln(out, "# EOF")
+	return out.Flush()
+}
+
+// WriteFile writes the metrics in the OpenMetrics text format to path. The file is replaced
+// atomically, so a scraper never reads a partial file.
+func (m *Metrics) WriteFile(path string) error {
+	m.fileMu.Lock()
+	defer m.fileMu.Unlock()
+
+	var content strings.Builder
+	if err := m.WriteOpenMetrics(&content); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, []byte(content.String()))
+}
+
+// writeHistograms writes one histogram family with a sample set per label value.
+func writeHistograms(out io.Writer, name string, help string, label string, histograms map[string]*histogram) {
+	fmt.Fprintf(out, "# TYPE %s%s histogram\n", metricsPrefix, name)
+	fmt.Fprintf(out, "# HELP %s%s %s\n", metricsPrefix, name, help)
+	for _, key := range sortedKeys(histograms) {
+		h := histograms[key]
+		labels := label + "=" + quoteLabel(key)
+		var cumulative uint64
+		for i, bound := range latencyBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(out, "%s%s_bucket{%s,le=%s} %d\n", metricsPrefix, name, labels, quoteLabel(strconv.FormatFloat(bound, 'f', -1, 64)), cumulative)
+		}
+		fmt.Fprintf(out, "%s%s_bucket{%s,le=\"+Inf\"} %d\n", metricsPrefix, name, labels, h.count)
+		fmt.Fprintf(out, "%s%s_sum{%s} %s\n", metricsPrefix, name, labels, strconv.FormatFloat(h.sum, 'f', -1, 64))
+		fmt.Fprintf(out, "%s%s_count{%s} %d\n", metricsPrefix, name, labels, h.count)
+	}
+}
+
+// quoteLabel quotes a label value, escaping backslashes, quotes and line feeds.
+func quoteLabel(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeFileAtomic writes content to a temporary file next to path and renames it to path.
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// CreateTemp only grants access to the owner, but the file is read by other tools
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
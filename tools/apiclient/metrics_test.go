@@ -0,0 +1,73 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsWriteOpenMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.observeRequest("RequestName", http.StatusOK, nil, 20*time.Millisecond)
+	metrics.observeRequest("RequestName", http.StatusBadGateway, nil, 2*time.Second)
+	metrics.observeRequest(`Odd"Name`, 0, context.DeadlineExceeded, time.Minute)
+	metrics.observeQueueWait("serial", 3*time.Millisecond)
+
+	var output strings.Builder
+	assert.NoError(t, metrics.WriteOpenMetrics(&output))
+	text := output.String()
+
+	assert.Contains(t, text, "# TYPE aznamingtool_requests counter\n")
+	assert.Contains(t, text, `aznamingtool_requests_total{endpoint="RequestName",status="200"} 1`)
+	assert.Contains(t, text, `aznamingtool_requests_total{endpoint="RequestName",status="502"} 1`)
+	assert.Contains(t, text, `aznamingtool_requests_total{endpoint="Odd\"Name",status="error"} 1`)
+	assert.Contains(t, text, `aznamingtool_request_errors_total{endpoint="RequestName"} 1`)
+	assert.Contains(t, text, `aznamingtool_request_duration_seconds_bucket{endpoint="RequestName",le="0.025"} 1`)
+	assert.Contains(t, text, `aznamingtool_request_duration_seconds_bucket{endpoint="RequestName",le="2.5"} 2`)
+	assert.Contains(t, text, `aznamingtool_request_duration_seconds_bucket{endpoint="RequestName",le="+Inf"} 2`)
+	assert.Contains(t, text, `aznamingtool_request_duration_seconds_count{endpoint="RequestName"} 2`)
+	assert.Contains(t, text, `aznamingtool_queue_wait_seconds_bucket{queue="serial",le="0.005"} 1`)
+	assert.True(t, strings.HasSuffix(text, "# EOF\n"))
+}
+
+func TestClientWritesMetricsFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"Success": true}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	client := newTestClient(t, server.URL, server.Client(), WithMetricsFile(path))
+	service := NewBaseService(client)
+	readFile := func() string {
+		content, _ := os.ReadFile(path)
+		return string(content)
+	}
+
+	// Nothing is written before a flush
+	var response models.ResourceNameResponse
+	assert.NoError(t, service.DoPost(context.Background(), EndpointRequestName, models.ResourceNameRequest{}, &response))
+	assert.Empty(t, readFile())
+
+	client.FlushMetrics(context.Background())
+	assert.Contains(t, readFile(), `aznamingtool_requests_total{endpoint="RequestName",status="200"} 1`)
+
+	// A flush without new requests does not rewrite the file
+	assert.NoError(t, os.Remove(path))
+	client.FlushMetrics(context.Background())
+	assert.Empty(t, readFile())
+
+	assert.NoError(t, service.DoPost(context.Background(), EndpointRequestName, models.ResourceNameRequest{}, &response))
+	assert.NoError(t, client.Close())
+	assert.Contains(t, readFile(), `aznamingtool_requests_total{endpoint="RequestName",status="200"} 2`)
+	assert.Contains(t, readFile(), `aznamingtool_queue_wait_seconds_count{queue="serial"} 2`)
+}
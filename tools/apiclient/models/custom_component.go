@@ -1,11 +1,11 @@
 package models
 
 type CustomComponent struct {
-	Id              int64
-	ParentComponent string
-	Name            string
-	ShortName       string
-	SortOrder       int
-	MinLength       int
-	MaxLength       int
+	Id              int64     `json:"id"`
+	ParentComponent string    `json:"parentComponent"`
+	Name            string    `json:"name"`
+	ShortName       string    `json:"shortName"`
+	SortOrder       int       `json:"sortOrder"`
+	MinLength       StringInt `json:"minLength"`
+	MaxLength       StringInt `json:"maxLength"`
 }
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringInt(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected StringInt
+		valid    bool
+	}{
+		{json: `"63"`, expected: 63, valid: true},
+		{json: `63`, expected: 63, valid: true},
+		{json: `" 5 "`, expected: 5, valid: true},
+		{json: `""`, expected: 0, valid: true},
+		{json: `null`, expected: 0, valid: true},
+		{json: `"abc"`, valid: false},
+		{json: `1.5`, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var value StringInt
+			err := json.Unmarshal([]byte(tt.json), &value)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	encoded, err := json.Marshal(StringInt(63))
+	assert.NoError(t, err)
+	assert.Equal(t, `"63"`, string(encoded))
+}
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected Bool
+		valid    bool
+	}{
+		{json: `true`, expected: true, valid: true},
+		{json: `false`, expected: false, valid: true},
+		{json: `"True"`, expected: true, valid: true},
+		{json: `"1"`, expected: true, valid: true},
+		{json: `"0"`, expected: false, valid: true},
+		{json: `""`, expected: false, valid: true},
+		{json: `null`, expected: false, valid: true},
+		{json: `"maybe"`, valid: false},
+		{json: `2`, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var value Bool
+			err := json.Unmarshal([]byte(tt.json), &value)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	encoded, err := json.Marshal(Bool(true))
+	assert.NoError(t, err)
+	assert.Equal(t, `true`, string(encoded))
+}
+
+// decodeStrict decodes a recorded Naming Tool response, failing on properties the models
+// do not know about so API changes between Naming Tool releases are noticed.
+func decodeStrict(t *testing.T, file string, response interface{}) {
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	assert.NoError(t, decoder.Decode(response))
+}
+
+func TestDecodeResourceTypes(t *testing.T) {
+	var types []ResourceType
+	decodeStrict(t, "testdata/resource_types.json", &types)
+
+	assert.Len(t, types, 1)
+	assert.Equal(t, StringInt(3), types[0].LengthMin)
+	assert.Equal(t, StringInt(63), types[0].LengthMax)
+	assert.True(t, bool(types[0].Enabled))
+}
+
+func TestResourceTypeComponents(t *testing.T) {
+	resourceType := ResourceType{Optional: "UnitDept", Exclude: " Org, ,Function"}
+	assert.Equal(t, []string{"UnitDept"}, resourceType.OptionalComponents())
+	assert.Equal(t, []string{"Org", "Function"}, resourceType.ExcludedComponents())
+
+	resourceType.SetOptionalComponents(nil)
+	resourceType.SetExcludedComponents([]string{"Org", "UnitDept"})
+	assert.Equal(t, "", resourceType.Optional)
+	assert.Equal(t, []string{}, resourceType.OptionalComponents())
+	assert.Equal(t, "Org,UnitDept", resourceType.Exclude)
+}
+
+func TestDecodeResourceComponents(t *testing.T) {
+	var components []ResourceComponent
+	decodeStrict(t, "testdata/resource_components.json", &components)
+
+	assert.Len(t, components, 1)
+	assert.Equal(t, StringInt(10), components[0].MaxLength)
+	assert.True(t, bool(components[0].ApplyDelimiterAfter))
+}
@@ -1,18 +1,25 @@
 package models
 
+import "strings"
+
 type ResourceBaseEntity struct {
-	Id        int32
-	Name      string
-	ShortName string
-	SortOrder int
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+// BaseEntity returns the fields shared by the component values, such as environments and locations.
+func (e ResourceBaseEntity) BaseEntity() ResourceBaseEntity {
+	return e
 }
 
 type ResourceDelimiter struct {
-	Id        int
-	Name      string
-	Delimiter string
-	Enabled   bool
-	SortOrder int
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	Delimiter string `json:"delimiter"`
+	Enabled   Bool   `json:"enabled"`
+	SortOrder int    `json:"sortOrder"`
 }
 
 type ResourceEnvironment struct {
@@ -40,82 +47,113 @@ type ResourceUnit struct {
 }
 
 type ResourceType struct {
-	Id                           int64
-	Resource                     string
-	Optional                     string
-	Exclude                      string
-	Property                     string
-	ShortName                    string
-	Scope                        string
-	LenghtMin                    string
-	LenghtMax                    string
-	ValidText                    string
-	InvalidText                  string
-	InvalidCharacters            string
-	InvalidCharactersStart       string
-	InvalidCharactersEnd         string
-	InvalidCharactersConsecutive string
-	Regx                         string
-	StaticValues                 string
-	Enabled                      bool
-	ApplyDelimiter               bool
+	Id                           int64     `json:"id"`
+	Resource                     string    `json:"resource"`
+	Optional                     string    `json:"optional"`
+	Exclude                      string    `json:"exclude"`
+	Property                     string    `json:"property"`
+	ShortName                    string    `json:"shortName"`
+	Scope                        string    `json:"scope"`
+	LengthMin                    StringInt `json:"lengthMin"`
+	LengthMax                    StringInt `json:"lengthMax"`
+	ValidText                    string    `json:"validText"`
+	InvalidText                  string    `json:"invalidText"`
+	InvalidCharacters            string    `json:"invalidCharacters"`
+	InvalidCharactersStart       string    `json:"invalidCharactersStart"`
+	InvalidCharactersEnd         string    `json:"invalidCharactersEnd"`
+	InvalidCharactersConsecutive string    `json:"invalidCharactersConsecutive"`
+	Regx                         string    `json:"regx"`
+	StaticValues                 string    `json:"staticValues"`
+	Enabled                      Bool      `json:"enabled"`
+	ApplyDelimiter               Bool      `json:"applyDelimiter"`
+}
+
+// OptionalComponents returns the components that may be left out of the names of the resource type.
+func (t ResourceType) OptionalComponents() []string {
+	return splitList(t.Optional)
+}
+
+// SetOptionalComponents sets the components that may be left out of the names of the resource type.
+func (t *ResourceType) SetOptionalComponents(components []string) {
+	t.Optional = strings.Join(components, ",")
+}
+
+// ExcludedComponents returns the components that are never part of the names of the resource type.
+func (t ResourceType) ExcludedComponents() []string {
+	return splitList(t.Exclude)
+}
+
+// SetExcludedComponents sets the components that are never part of the names of the resource type.
+func (t *ResourceType) SetExcludedComponents(components []string) {
+	t.Exclude = strings.Join(components, ",")
+}
+
+// splitList splits a comma-separated list as stored by the Naming Tool, dropping empty items.
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 type ResourceNameRequest struct {
-	ResourceEnvironment string
-	ResourceFunction    string
-	ResourceInstance    string
-	ResourceLocation    string
-	ResourceOrg         string
-	ResourceProjAppSvc  string
-	ResourceType        string
-	ResourceUnitDept    string
-	CustomComponents    map[string]string
-	ResourceId          int64
-	CreatedBy           string
+	ResourceEnvironment string            `json:"resourceEnvironment"`
+	ResourceFunction    string            `json:"resourceFunction"`
+	ResourceInstance    string            `json:"resourceInstance"`
+	ResourceLocation    string            `json:"resourceLocation"`
+	ResourceOrg         string            `json:"resourceOrg"`
+	ResourceProjAppSvc  string            `json:"resourceProjAppSvc"`
+	ResourceType        string            `json:"resourceType"`
+	ResourceUnitDept    string            `json:"resourceUnitDept"`
+	CustomComponents    map[string]string `json:"customComponents"`
+	ResourceId          int64             `json:"resourceId"`
+	CreatedBy           string            `json:"createdBy"`
 }
 
 type ResourceNameRequestWithComponents struct {
-	ResourceEnvironment ResourceEnvironment
-	ResourceFunction    ResourceFunction
-	ResourceDelimiter   ResourceDelimiter
-	ResourceInstance    string
-	ResourceLocation    ResourceLocation
-	ResourceOrg         ResourceOrganization
-	ResourceProjAppSvc  ResourceProject
-	ResourceType        ResourceType
-	ResourceUnitDept    ResourceUnit
+	ResourceEnvironment ResourceEnvironment  `json:"resourceEnvironment"`
+	ResourceFunction    ResourceFunction     `json:"resourceFunction"`
+	ResourceDelimiter   ResourceDelimiter    `json:"resourceDelimiter"`
+	ResourceInstance    string               `json:"resourceInstance"`
+	ResourceLocation    ResourceLocation     `json:"resourceLocation"`
+	ResourceOrg         ResourceOrganization `json:"resourceOrg"`
+	ResourceProjAppSvc  ResourceProject      `json:"resourceProjAppSvc"`
+	ResourceType        ResourceType         `json:"resourceType"`
+	ResourceUnitDept    ResourceUnit         `json:"resourceUnitDept"`
 }
 
 type ResourceGeneratedName struct {
-	Id               int64
-	CreatedOn        string
-	ResourceName     string
-	ResourceTypeName string
-	User             string
-	Message          string
-	Components       [][]string
+	Id               int64      `json:"id"`
+	CreatedOn        string     `json:"createdOn"`
+	ResourceName     string     `json:"resourceName"`
+	ResourceTypeName string     `json:"resourceTypeName"`
+	User             string     `json:"user"`
+	Message          string     `json:"message"`
+	Components       [][]string `json:"components"`
 }
 
 type ResourceNameResponse struct {
-	ResourceName        string
-	Message             string
-	Success             bool
-	ResourceNameDetails ResourceGeneratedName
+	ResourceName        string                `json:"resourceName"`
+	Message             string                `json:"message"`
+	Success             bool                  `json:"success"`
+	ResourceNameDetails ResourceGeneratedName `json:"resourceNameDetails"`
 }
 
 type ResourceComponent struct {
-	Id                   int64
-	Name                 string
-	DisplayName          string
-	Enabled              bool
-	SortOrder            int
-	IsCustom             bool
-	IsFreeText           bool
-	MinLength            string
-	MaxLength            string
-	EnforceRandom        bool
-	Alphanumeric         bool
-	ApplyDelimiterBefore bool
-	ApplyDelimiterAfter  bool
+	Id                   int64     `json:"id"`
+	Name                 string    `json:"name"`
+	DisplayName          string    `json:"displayName"`
+	Enabled              Bool      `json:"enabled"`
+	SortOrder            int       `json:"sortOrder"`
+	IsCustom             Bool      `json:"isCustom"`
+	IsFreeText           Bool      `json:"isFreeText"`
+	MinLength            StringInt `json:"minLength"`
+	MaxLength            StringInt `json:"maxLength"`
+	EnforceRandom        Bool      `json:"enforceRandom"`
+	Alphanumeric         Bool      `json:"alphanumeric"`
+	ApplyDelimiterBefore Bool      `json:"applyDelimiterBefore"`
+	ApplyDelimiterAfter  Bool      `json:"applyDelimiterAfter"`
 }
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StringInt is an integer that the Naming Tool stores as a string, such as the length
+// limits of resource types. It is decoded from JSON numbers and numeric strings, an empty
+// string or null is decoded as zero, and it is encoded as a JSON string.
+type StringInt int64
+
+// UnmarshalJSON decodes a JSON number, numeric string or null.
+func (i *StringInt) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalInt(data)
+	if err != nil {
+		return err
+	}
+	*i = StringInt(value)
+	return nil
+}
+
+// MarshalJSON encodes the integer as a JSON string.
+func (i StringInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(i), 10))
+}
+
+// Bool is a boolean that is also decoded from the strings "true", "false", "1" and "0",
+// as stored by some Naming Tool releases. An empty string or null is decoded as false.
+// It is encoded as a JSON boolean.
+type Bool bool
+
+// UnmarshalJSON decodes a JSON boolean, boolean string or null.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*b = false
+		return nil
+	}
+
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = Bool(value)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("cannot decode %s as a boolean", data)
+	}
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "":
+		*b = false
+	default:
+		return fmt.Errorf("cannot decode %q as a boolean", text)
+	}
+	return nil
+}
+
+// unmarshalInt decodes a JSON number, numeric string or null as an integer.
+func unmarshalInt(data []byte) (int64, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return 0, nil
+	}
+
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return 0, err
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return 0, nil
+		}
+	}
+
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot decode %s as an integer", data)
+	}
+	return value, nil
+}
@@ -1,13 +1,13 @@
 package models
 
 type ValidateNameRequest struct {
-	ResourceTypeId int
-	ResourceType   string
-	Name           string
+	ResourceTypeId int64  `json:"resourceTypeId"`
+	ResourceType   string `json:"resourceType"`
+	Name           string `json:"name"`
 }
 
 type ValidateNameResponse struct {
-	Valid   bool
-	Name    string
-	Message string
+	Valid   bool   `json:"valid"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
 }
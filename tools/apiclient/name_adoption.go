@@ -0,0 +1,144 @@
+package apiclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+const (
+	// DefaultCreatedBy is recorded as the creator of the names requested by the client.
+	DefaultCreatedBy = "Terraform"
+	// DefaultAdoptionWindow is how old a generated name may be to be adopted by RequestNameOnce.
+	// Adoption is disabled by default.
+	DefaultAdoptionWindow = time.Duration(0)
+)
+
+// createdOnLayouts are the formats of the creation time in the generated names log.
+var createdOnLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.9999999", "2006-01-02 15:04:05", "1/2/2006 3:04:05 PM"}
+
+// WithCreatedBy sets the creator recorded with the names requested by the client.
+func WithCreatedBy(createdBy string) ClientOption {
+	return func(c *APIClient) {
+		c.CreatedBy = createdBy
+	}
+}
+
+// WithAdoptionWindow enables adoption by RequestNameOnce and sets how old a generated name
+// may be to be adopted. Zero disables adoption.
+func WithAdoptionWindow(window time.Duration) ClientOption {
+	return func(c *APIClient) {
+		c.AdoptionWindow = window
+	}
+}
+
+// RequestNameOnce requests a name like RequestName. When adoption is enabled with the
+// client's AdoptionWindow, the creator of the request is made unique with a random marker,
+// and a request that fails without a clear answer is looked up in the generated names log
+// by that marker. The Naming Tool may have stored the name before the request timed out,
+// and adopting it avoids generating a duplicate. Names of other requests are never adopted.
+//
+// Parameters:
+//   - ctx: The context used to cancel the requests.
+//   - request: The name request. Its CreatedBy defaults to the client's CreatedBy.
+//
+// Returns:
+//   - A pointer to the generated or adopted name.
+//   - Whether the name was adopted from the generated names log.
+//   - An error if the request fails and no name was found.
+func (s *ResourceNamingService) RequestNameOnce(ctx context.Context, request *models.ResourceNameRequest) (*models.ResourceGeneratedName, bool, error) {
+	client := s.baseService.client
+	if request.CreatedBy == "" {
+		request.CreatedBy = client.CreatedBy
+	}
+	if client.AdoptionWindow > 0 {
+		marker, err := requestMarker(request.CreatedBy)
+		if err != nil {
+			return nil, false, err
+		}
+		request.CreatedBy = marker
+	}
+
+	response, requestErr := s.RequestName(ctx, request)
+	if requestErr == nil {
+		return &response.ResourceNameDetails, false, nil
+	}
+	if client.AdoptionWindow <= 0 || response != nil || !isAmbiguousFailure(ctx, requestErr) {
+		return nil, false, requestErr
+	}
+
+	adopted, err := s.findRequestedName(ctx, request.CreatedBy)
+	if err != nil {
+		tflog.Warn(ctx, "Cannot search the generated names log for a name to adopt", map[string]interface{}{"error": err.Error()})
+		return nil, false, requestErr
+	}
+	if adopted == nil {
+		return nil, false, requestErr
+	}
+	return adopted, true, nil
+}
+
+// findRequestedName returns the name of the generated names log created by marker within the
+// adoption window, or nil if the Naming Tool did not store one.
+func (s *ResourceNamingService) findRequestedName(ctx context.Context, marker string) (*models.ResourceGeneratedName, error) {
+	client := s.baseService.client
+	names, err := s.ListGeneratedNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i, name := range names {
+		createdOn, ok := parseCreatedOn(name.CreatedOn)
+		if !ok || name.User != marker {
+			continue
+		}
+		if age := now.Sub(createdOn); age > client.AdoptionWindow || age < -client.AdoptionWindow {
+			continue
+		}
+		tflog.Info(ctx, "Adopting name stored by a request that failed", map[string]interface{}{"id": name.Id, "name": name.ResourceName, "created_on": name.CreatedOn})
+		return &names[i], nil
+	}
+	return nil, nil
+}
+
+// requestMarker appends a random suffix to createdBy, such as "Terraform#3f9a1c2b4d5e6f70",
+// so that the generated names log identifies the name of a single request.
+func requestMarker(createdBy string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate the request marker: %w", err)
+	}
+	return createdBy + "#" + hex.EncodeToString(suffix), nil
+}
+
+// parseCreatedOn parses the creation time of a generated name. Times without a zone are read as UTC.
+func parseCreatedOn(value string) (time.Time, bool) {
+	for _, layout := range createdOnLayouts {
+		if createdOn, err := time.Parse(layout, value); err == nil {
+			return createdOn, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isAmbiguousFailure reports whether a failed name request may still have been processed,
+// because it timed out, lost its connection or failed with a server error, rather than
+// being rejected before it was sent.
+func isAmbiguousFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, utils.ErrQueueFull) || errors.Is(err, utils.ErrClientClosed) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return !isDialError(err)
+}
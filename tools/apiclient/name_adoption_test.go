@@ -0,0 +1,123 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// namesLogServer is a Naming Tool that logs the generated names and answers name requests
+// after delay.
+type namesLogServer struct {
+	mu       sync.Mutex
+	names    []models.ResourceGeneratedName
+	requests int
+	lists    int
+	delay    time.Duration
+}
+
+func (s *namesLogServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case EndpointGetAllGeneratedNames.Path:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.lists++
+		json.NewEncoder(w).Encode(s.names)
+	case EndpointRequestName.Path:
+		var request models.ResourceNameRequest
+		json.NewDecoder(r.Body).Decode(&request)
+
+		s.mu.Lock()
+		s.requests++
+		name := s.add(request.CreatedBy, time.Now(), request.ResourceType, request.ResourceEnvironment)
+		s.mu.Unlock()
+
+		time.Sleep(s.delay)
+		json.NewEncoder(w).Encode(models.ResourceNameResponse{Success: true, ResourceName: name.ResourceName, ResourceNameDetails: name})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// add logs a generated name. The caller must hold mu.
+func (s *namesLogServer) add(user string, createdOn time.Time, resourceType string, environment string) models.ResourceGeneratedName {
+	name := models.ResourceGeneratedName{
+		Id:           int64(len(s.names) + 1),
+		CreatedOn:    createdOn.UTC().Format("2006-01-02T15:04:05.9999999"),
+		ResourceName: resourceType + "-" + environment,
+		User:         user,
+		Components:   [][]string{{"ResourceType", resourceType}, {"ResourceEnvironment", environment}, {"ResourceDelimiter", "-"}},
+	}
+	s.names = append(s.names, name)
+	return name
+}
+
+func TestRequestNameOnceAdoptsNameAfterTimeout(t *testing.T) {
+	server := &namesLogServer{delay: 200 * time.Millisecond}
+	server.add(DefaultCreatedBy, time.Now(), "vm", "dev")
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	// The Naming Tool stores the name, but the response arrives after the client gave up
+	httpClient := httpServer.Client()
+	httpClient.Timeout = 50 * time.Millisecond
+	client := newTestClient(t, httpServer.URL, httpClient, WithAdoptionWindow(15*time.Minute))
+
+	request := &models.ResourceNameRequest{ResourceType: "vm", ResourceEnvironment: "dev"}
+	name, adopted, err := NewResourceNamingService(client).RequestNameOnce(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, adopted)
+	assert.Equal(t, 1, server.requests)
+
+	// The name of the request is adopted, not the older one with the same components
+	assert.Equal(t, int64(2), name.Id)
+	assert.Equal(t, request.CreatedBy, name.User)
+	assert.Regexp(t, "^Terraform#[0-9a-f]{16}$", request.CreatedBy)
+}
+
+func TestRequestNameOnceDoesNotAdoptOtherNames(t *testing.T) {
+	server := &namesLogServer{}
+	server.add(DefaultCreatedBy, time.Now(), "vm", "dev")
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	client := newTestClient(t, httpServer.URL, httpServer.Client(), WithAdoptionWindow(15*time.Minute))
+	service := NewResourceNamingService(client)
+	ids := map[int64]bool{}
+	for i := 0; i < 3; i++ {
+		name, adopted, err := service.RequestNameOnce(context.Background(), &models.ResourceNameRequest{ResourceType: "vm", ResourceEnvironment: "dev"})
+		assert.NoError(t, err)
+		assert.False(t, adopted)
+		ids[name.Id] = true
+	}
+
+	// Every request generates its own name, and the log is not searched after a success
+	assert.Len(t, ids, 3)
+	assert.Equal(t, 3, server.requests)
+	assert.Equal(t, 0, server.lists)
+}
+
+func TestRequestNameOnceWithoutAdoption(t *testing.T) {
+	server := &namesLogServer{delay: 200 * time.Millisecond}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	// Adoption is disabled by default, so the failed request is not looked up
+	httpClient := httpServer.Client()
+	httpClient.Timeout = 50 * time.Millisecond
+	client := newTestClient(t, httpServer.URL, httpClient, WithCreatedBy("pipeline"))
+	request := &models.ResourceNameRequest{ResourceType: "vm", ResourceEnvironment: "dev"}
+	_, adopted, err := NewResourceNamingService(client).RequestNameOnce(context.Background(), request)
+
+	assert.Error(t, err)
+	assert.False(t, adopted)
+	assert.Equal(t, 0, server.lists)
+	assert.Equal(t, "pipeline", request.CreatedBy)
+}
@@ -0,0 +1,92 @@
+package apiclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RateLimiter is a token bucket that limits the rate of requests sent to the Naming Tool.
+// It is shared by every queue worker of an APIClient.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64   // The number of tokens added per second.
+	burst  float64   // The maximum number of tokens in the bucket.
+	tokens float64   // The tokens currently available. Negative values are reserved by waiting callers.
+	last   time.Time // The last time tokens were added.
+}
+
+// NewRateLimiter creates a new token bucket allowing requestsPerSecond on average and
+// up to burst requests at once.
+//
+// Parameters:
+//   - requestsPerSecond: The average number of requests allowed per second. Must be greater than zero.
+//   - burst: The number of requests allowed at once. Values below 1 use the rounded-up rate.
+//
+// Returns:
+//   - A pointer to the newly created RateLimiter, starting with a full bucket.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = int(math.Max(1, math.Ceil(requestsPerSecond)))
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+//
+// Parameters:
+//   - ctx: The context used to cancel the wait.
+//
+// Returns:
+//   - The context error if ctx was done before a token became available.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Waiting for the Naming Tool rate limit", map[string]interface{}{
+		"wait": wait.String(),
+	})
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait for it.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
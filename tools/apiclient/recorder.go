@@ -0,0 +1,298 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+const (
+	// RecorderModeEnv selects the recorder mode: "record", "replay" or empty to disable it.
+	RecorderModeEnv = "AZ_NAMINGTOOL_RECORDER_MODE"
+	// RecorderCassetteEnv is the path of the cassette file written or read by the recorder.
+	RecorderCassetteEnv = "AZ_NAMINGTOOL_CASSETTE"
+
+	// cassetteVersion is the version of the cassette file format.
+	cassetteVersion = 1
+	// redactedValue replaces secrets in recorded interactions.
+	redactedValue = "REDACTED"
+)
+
+// RecorderMode selects whether the recorder captures or replays interactions.
+type RecorderMode string
+
+const (
+	// RecorderModeRecord sends requests to the Naming Tool and saves every interaction to the cassette.
+	RecorderModeRecord RecorderMode = "record"
+	// RecorderModeReplay answers requests from the cassette without contacting the Naming Tool.
+	RecorderModeReplay RecorderMode = "replay"
+)
+
+// Cassette holds the recorded interactions with the Naming Tool.
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response of the Naming Tool.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of an interaction. The URL has no scheme and host, so the
+// cassette can be replayed against any base URL.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response of an interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecorderTransport is an http.RoundTripper that records interactions with the Naming Tool
+// to a cassette file, or replays them from it. Credentials in headers, secrets in JSON
+// bodies and the configured Secrets are replaced before anything is written.
+type RecorderTransport struct {
+	Mode         RecorderMode      // Whether interactions are recorded or replayed.
+	CassettePath string            // The path of the cassette file.
+	Transport    http.RoundTripper // The transport used to reach the Naming Tool when recording. Defaults to http.DefaultTransport.
+	Secrets      []string          // Values scrubbed wherever they appear, such as the API key.
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool // Which interactions were already replayed
+}
+
+// NewRecorderTransport creates a recorder for the cassette at path. In replay mode the
+// cassette is loaded immediately.
+//
+// Parameters:
+//   - mode: Whether interactions are recorded or replayed.
+//   - path: The path of the cassette file.
+//   - transport: The transport used to reach the Naming Tool when recording, or nil.
+//   - secrets: Values scrubbed from the recorded interactions.
+//
+// Returns:
+//   - A pointer to the RecorderTransport.
+//   - An error if the mode is unknown or the cassette cannot be loaded.
+func NewRecorderTransport(mode RecorderMode, path string, transport http.RoundTripper, secrets ...string) (*RecorderTransport, error) {
+	recorder := &RecorderTransport{Mode: mode, CassettePath: path, Transport: transport, cassette: Cassette{Version: cassetteVersion}}
+	for _, secret := range secrets {
+		if secret != "" {
+			recorder.Secrets = append(recorder.Secrets, secret)
+		}
+	}
+
+	switch mode {
+	case RecorderModeRecord:
+	case RecorderModeReplay:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(content, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+		if recorder.cassette.Version != cassetteVersion {
+			return nil, fmt.Errorf("cassette %s has version %d, expected %d", path, recorder.cassette.Version, cassetteVersion)
+		}
+		recorder.used = make([]bool, len(recorder.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q, use %q or %q", mode, RecorderModeRecord, RecorderModeReplay)
+	}
+	return recorder, nil
+}
+
+// RecorderFromEnv wraps transport in a RecorderTransport when the AZ_NAMINGTOOL_RECORDER_MODE
+// environment variable is set, using the cassette named by AZ_NAMINGTOOL_CASSETTE.
+//
+// Returns:
+//   - The recorder, or transport unchanged when recording is disabled.
+//   - An error if the cassette is not set or cannot be loaded.
+func RecorderFromEnv(transport http.RoundTripper, secrets ...string) (http.RoundTripper, error) {
+	mode := os.Getenv(RecorderModeEnv)
+	if mode == "" {
+		return transport, nil
+	}
+	path := os.Getenv(RecorderCassetteEnv)
+	if path == "" {
+		return nil, fmt.Errorf("%s must be set when %s is %q", RecorderCassetteEnv, RecorderModeEnv, mode)
+	}
+	return NewRecorderTransport(RecorderMode(strings.ToLower(mode)), path, transport, secrets...)
+}
+
+// RoundTrip records or replays the request.
+func (r *RecorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		// The body was consumed, so send a copy of the request with a fresh body
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := r.recordRequest(req, body)
+
+	if r.Mode == RecorderModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+// record sends the request and appends the interaction to the cassette file.
+func (r *RecorderTransport) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrubHeader(resp.Header),
+			Body:       r.scrub(string(body)),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replay answers the request with the first unused interaction with the same method, URL and body.
+func (r *RecorderTransport) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		// Scrubbing may have changed the length of the body
+		header.Del("Content-Length")
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", utils.ErrNoRecordedInteraction, recorded.Method, recorded.URL)
+}
+
+// save writes the cassette atomically, so an interrupted run never leaves a truncated cassette.
+func (r *RecorderTransport) save() error {
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(r.CassettePath, append(content, '\n')); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// recordRequest returns the scrubbed form of req stored in and matched against the cassette.
+func (r *RecorderTransport) recordRequest(req *http.Request, body []byte) RecordedRequest {
+	return RecordedRequest{
+		Method: req.Method,
+		URL:    r.scrub(req.URL.RequestURI()),
+		Header: r.scrubHeader(req.Header),
+		Body:   r.scrub(string(body)),
+	}
+}
+
+// scrubHeader returns a copy of header with the values of credential headers replaced.
+func (r *RecorderTransport) scrubHeader(header http.Header) http.Header {
+	scrubbed := make(http.Header, len(header))
+	for name, values := range header {
+		scrubbed[name] = make([]string, len(values))
+		for i, value := range values {
+			scrubbed[name][i] = r.scrub(value)
+		}
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := scrubbed[http.CanonicalHeaderKey(name)]; ok {
+			scrubbed.Set(name, redactedValue)
+		}
+	}
+	return scrubbed
+}
+
+// scrub replaces the configured secrets and the secret properties of JSON bodies in value.
+func (r *RecorderTransport) scrub(value string) string {
+	for _, secret := range r.Secrets {
+		value = strings.ReplaceAll(value, secret, redactedValue)
+	}
+	return sensitiveBodyFields.ReplaceAllString(value, `${1}"`+redactedValue+`"`)
+}
+
+// matches reports whether the recorded request is the same request as other.
+func (r RecordedRequest) matches(other RecordedRequest) bool {
+	return r.Method == other.Method && r.URL == other.URL && sameBody(r.Body, other.Body)
+}
+
+// sameBody compares two bodies, ignoring the formatting of JSON bodies.
+func sameBody(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	var decodedA, decodedB interface{}
+	if json.Unmarshal([]byte(a), &decodedA) != nil || json.Unmarshal([]byte(b), &decodedB) != nil {
+		return false
+	}
+	encodedA, _ := json.Marshal(decodedA)
+	encodedB, _ := json.Marshal(decodedB)
+	return bytes.Equal(encodedA, encodedB)
+}
+
+// readRequestBody reads and closes the body of req.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
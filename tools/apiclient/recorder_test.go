@@ -0,0 +1,87 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"Id": 7, "Name": "unit", "Password": "hunter2"}`))
+			return
+		}
+		w.Write([]byte(`[{"Id": 1, "Resource": "Resource1"}]`))
+	}))
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := NewRecorderTransport(RecorderModeRecord, cassette, server.Client().Transport, "123456")
+	assert.NoError(t, err)
+	service := NewBaseService(newTestClient(t, server.URL, &http.Client{Transport: recorder}))
+
+	var types []models.ResourceType
+	var unit models.ResourceUnit
+	assert.NoError(t, service.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &types))
+	assert.NoError(t, service.DoPost(context.Background(), EndpointCreateOrUpdateResourceUnit, map[string]string{"Name": "unit", "Note": "key 123456"}, &unit))
+	server.Close()
+
+	content, err := os.ReadFile(cassette)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "123456")
+	assert.NotContains(t, string(content), "hunter2")
+	assert.NotContains(t, string(content), "session=abc")
+	assert.NotContains(t, string(content), server.URL)
+
+	replayer, err := NewRecorderTransport(RecorderModeReplay, cassette, nil, "123456")
+	assert.NoError(t, err)
+	client := newTestClient(t, "https://replay.example.com", &http.Client{Transport: replayer})
+	client.RetryPolicy.MaxAttempts = 1
+	service = NewBaseService(client)
+
+	types, unit = nil, models.ResourceUnit{}
+	assert.NoError(t, service.DoPost(context.Background(), EndpointCreateOrUpdateResourceUnit, map[string]string{"Note": "key 123456", "Name": "unit"}, &unit))
+	assert.NoError(t, service.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &types))
+	assert.Equal(t, "Resource1", types[0].Resource)
+	assert.Equal(t, int64(7), unit.Id)
+
+	// Every interaction is replayed once
+	err = service.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &types)
+	assert.ErrorIs(t, err, utils.ErrNoRecordedInteraction)
+}
+
+func TestRecorderFromEnv(t *testing.T) {
+	transport := http.DefaultTransport
+
+	t.Setenv(RecorderModeEnv, "")
+	result, err := RecorderFromEnv(transport)
+	assert.NoError(t, err)
+	assert.Equal(t, transport, result)
+
+	t.Setenv(RecorderModeEnv, "record")
+	t.Setenv(RecorderCassetteEnv, "")
+	_, err = RecorderFromEnv(transport)
+	assert.ErrorContains(t, err, RecorderCassetteEnv)
+
+	t.Setenv(RecorderCassetteEnv, filepath.Join(t.TempDir(), "cassette.json"))
+	result, err = RecorderFromEnv(transport)
+	assert.NoError(t, err)
+	assert.IsType(t, &RecorderTransport{}, result)
+
+	t.Setenv(RecorderModeEnv, "replay")
+	_, err = RecorderFromEnv(transport)
+	assert.ErrorContains(t, err, "failed to read cassette")
+
+	t.Setenv(RecorderModeEnv, "rewind")
+	_, err = RecorderFromEnv(transport)
+	assert.ErrorContains(t, err, "unknown recorder mode")
+}
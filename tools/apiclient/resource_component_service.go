@@ -1,6 +1,13 @@
 package apiclient
 
-import "github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
 
 type ResourceComponentService struct {
 	baseService *BaseService
@@ -19,12 +26,15 @@ func NewResourceComponentService(client *APIClient) *ResourceComponentService {
 
 // GetAllResourceComponents retrieves all resource components.
 //
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//
 // Returns:
 //   - A pointer to a slice of models.ResourceComponent containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceComponentService) GetAllResourceComponents() (*[]models.ResourceComponent, error) {
+func (s *ResourceComponentService) GetAllResourceComponents(ctx context.Context) (*[]models.ResourceComponent, error) {
 	var response []models.ResourceComponent
-	err := s.baseService.DoGet("RequestName", nil, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetAllResourceComponents, nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -35,14 +45,15 @@ func (s *ResourceComponentService) GetAllResourceComponents() (*[]models.Resourc
 // GetResourceComponent retrieves a resource component based on the provided ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - id: A string representing the ID of the resource component.
 //
 // Returns:
 //   - A pointer to models.ResourceComponent containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceComponentService) GetResourceComponent(id string) (*models.ResourceComponent, error) {
+func (s *ResourceComponentService) GetResourceComponent(ctx context.Context, id string) (*models.ResourceComponent, error) {
 	var response models.ResourceComponent
-	err := s.baseService.DoGet("GetResourceComponent", map[string]string{"id": id}, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetResourceComponent, map[string]string{"id": id}, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -53,17 +64,155 @@ func (s *ResourceComponentService) GetResourceComponent(id string) (*models.Reso
 // CreateOrUpdateResourceComponent creates or updates a resource component based on the provided request data.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - request: An instance of models.ResourceComponent containing the request data.
 //
 // Returns:
-//   - A pointer to models.ResourceComponent containing the response data.
+//   - A pointer to models.ResourceComponent containing the response data, without ID if the
+//     Naming Tool did not answer with the resource component.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceComponentService) CreateOrUpdateResourceComponent(request models.ResourceComponent) (*models.ResourceComponent, error) {
-	var response models.ResourceComponent
-	err := s.baseService.DoPost("CreateOrUpdateResourceComponent", request, &response)
+func (s *ResourceComponentService) CreateOrUpdateResourceComponent(ctx context.Context, request models.ResourceComponent) (*models.ResourceComponent, error) {
+	return postWrite[models.ResourceComponent](ctx, s.baseService, EndpointCreateOrUpdateResourceComponent, request)
+}
+
+// UpdateResourceComponent updates an existing resource component. A changed sort order is
+// rejected when another component already uses it, since the positions of the components
+// in generated names must be unique. The components are read bypassing the cache, and
+// concurrent updates through the client are serialized, so that two components cannot
+// take the same sort order.
+//
+// Parameters:
+//   - ctx: The context used to cancel the requests.
+//   - component: The resource component to update, with its ID.
+//
+// Returns:
+//   - An error matching utils.ErrNotFound if the component does not exist, utils.ErrConflict
+//     if its new sort order is taken, or an error if a request fails.
+func (s *ResourceComponentService) UpdateResourceComponent(ctx context.Context, component models.ResourceComponent) error {
+	client := s.baseService.client
+	client.componentsMu.Lock()
+	defer client.componentsMu.Unlock()
+
+	components, err := s.listUncached(ctx)
+	if err != nil {
+		return err
+	}
+
+	var current *models.ResourceComponent
+	for i := range components {
+		if components[i].Id == component.Id {
+			current = &components[i]
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("%w: component %d", utils.ErrNotFound, component.Id)
+	}
+	if current.SortOrder != component.SortOrder {
+		if err := checkSortOrder(components, component); err != nil {
+			return err
+		}
+	}
+
+	return s.baseService.DoPost(ctx, EndpointCreateOrUpdateResourceComponent, component, nil)
+}
+
+// CreateResourceComponent adds a resource component, such as a custom component. A component
+// without sort order is placed after all components, and a sort order that another component
+// already uses is rejected. Like UpdateResourceComponent, the components are read bypassing
+// the cache and concurrent writes through the client are serialized.
+//
+// Parameters:
+//   - ctx: The context used to cancel the requests.
+//   - component: The resource component to create, without ID.
+//
+// Returns:
+//   - A pointer to the created component, with its ID and sort order.
+//   - An error matching utils.ErrConflict if its sort order is taken, utils.ErrNotFound if
+//     the created component cannot be found, or an error if a request fails.
+func (s *ResourceComponentService) CreateResourceComponent(ctx context.Context, component models.ResourceComponent) (*models.ResourceComponent, error) {
+	client := s.baseService.client
+	client.componentsMu.Lock()
+	defer client.componentsMu.Unlock()
+
+	components, err := s.listUncached(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int64]bool, len(components))
+	for _, existing := range components {
+		known[existing.Id] = true
+	}
+	if component.SortOrder == 0 {
+		for _, existing := range components {
+			if component.SortOrder <= existing.SortOrder {
+				component.SortOrder = existing.SortOrder + 1
+			}
+		}
+	} else if err := checkSortOrder(components, component); err != nil {
+		return nil, err
+	}
+
+	created, err := postWrite[models.ResourceComponent](ctx, s.baseService, EndpointCreateOrUpdateResourceComponent, component)
 	if err != nil {
 		return nil, err
 	}
+	if created.Id != 0 {
+		return created, nil
+	}
 
-	return &response, nil
+	// The Naming Tool did not answer with the component, so look it up among the new ones
+	components, err = s.listUncached(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range components {
+		if !known[components[i].Id] && strings.EqualFold(components[i].Name, component.Name) {
+			return &components[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: the created component %q is not listed by the Naming Tool", utils.ErrNotFound, component.Name)
+}
+
+// listUncached retrieves all resource components from the Naming Tool, bypassing the cache.
+func (s *ResourceComponentService) listUncached(ctx context.Context) ([]models.ResourceComponent, error) {
+	uncached := EndpointGetAllResourceComponents
+	uncached.Cacheable = false
+	var components []models.ResourceComponent
+	if err := s.baseService.DoGet(ctx, uncached, nil, &components); err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+// checkSortOrder returns an error matching utils.ErrConflict if another of components uses
+// the sort order of component.
+func checkSortOrder(components []models.ResourceComponent, component models.ResourceComponent) error {
+	for _, other := range components {
+		if other.Id != component.Id && other.SortOrder == component.SortOrder {
+			return fmt.Errorf("%w: the sort order %d is already used by the component %q", utils.ErrConflict, component.SortOrder, other.Name)
+		}
+	}
+	return nil
+}
+
+// FindResourceComponentByName returns the resource component whose name matches name, ignoring case.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - name: The name of the component, such as "ResourceEnvironment".
+//
+// Returns:
+//   - A pointer to models.ResourceComponent.
+//   - An error matching utils.ErrNotFound if no component has the name, or an error if the request fails.
+func (s *ResourceComponentService) FindResourceComponentByName(ctx context.Context, name string) (*models.ResourceComponent, error) {
+	components, err := s.GetAllResourceComponents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *components {
+		if strings.EqualFold((*components)[i].Name, name) {
+			return &(*components)[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: component %q", utils.ErrNotFound, name)
 }
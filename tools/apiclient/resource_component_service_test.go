@@ -0,0 +1,72 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateResourceComponent(t *testing.T) {
+	server := namingtooltest.NewServer(t)
+	server.Put(namingtooltest.Components,
+		models.ResourceComponent{Id: 1, Name: "ResourceType", Enabled: true, SortOrder: 1},
+		models.ResourceComponent{Id: 2, Name: "ResourceEnvironment", Enabled: true, SortOrder: 4},
+	)
+	client := newTestClient(t, server.URL, server.Client(), WithCache(time.Hour))
+	service := NewResourceComponentService(client)
+	if _, err := service.GetAllResourceComponents(context.Background()); !assert.NoError(t, err) {
+		return
+	}
+
+	// A component added after the list was cached is not skipped
+	server.Put(namingtooltest.Components, models.ResourceComponent{Id: 3, Name: "ResourceLocation", Enabled: true, SortOrder: 6})
+	created, err := service.CreateResourceComponent(context.Background(), models.ResourceComponent{Name: "cost_center", IsCustom: true, Enabled: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(4), created.Id)
+		assert.Equal(t, 7, created.SortOrder)
+	}
+
+	// A sort order used by a built-in component is rejected before anything is written
+	_, err = service.CreateResourceComponent(context.Background(), models.ResourceComponent{Name: "project", IsCustom: true, Enabled: true, SortOrder: 4})
+	assert.ErrorIs(t, err, utils.ErrConflict)
+	assert.Equal(t, 1, server.Writes(namingtooltest.Components))
+}
+
+func TestCreateResourceComponentConcurrently(t *testing.T) {
+	server := namingtooltest.NewServer(t)
+	server.Put(namingtooltest.Components, models.ResourceComponent{Id: 1, Name: "ResourceType", Enabled: true, SortOrder: 1})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A slow list leaves room for the other create to read the same components
+		if r.Method == http.MethodGet && r.URL.Path == namingtooltest.Components {
+			time.Sleep(20 * time.Millisecond)
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer slow.Close()
+
+	service := NewResourceComponentService(newTestClient(t, slow.URL, slow.Client(), WithCache(time.Hour)))
+	var wg sync.WaitGroup
+	for _, name := range []string{"cost_center", "project"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.CreateResourceComponent(context.Background(), models.ResourceComponent{Name: name, IsCustom: true, Enabled: true})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Each component gets its own sort order
+	components := namingtooltest.Records[models.ResourceComponent](server, namingtooltest.Components)
+	if assert.Len(t, components, 3) {
+		assert.ElementsMatch(t, []int{2, 3}, []int{components[1].SortOrder, components[2].SortOrder})
+	}
+}
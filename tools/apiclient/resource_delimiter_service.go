@@ -1,6 +1,12 @@
 package apiclient
 
-import "github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+)
 
 type ResourceDelimiterService struct {
 	baseService *BaseService
@@ -19,12 +25,15 @@ func NewResourceDelimiterService(client *APIClient) *ResourceDelimiterService {
 
 // GetAllResourceDelimiters retrieves all resource delimiters.
 //
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//
 // Returns:
 //   - A pointer to a slice of models.ResourceDelimiter containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceDelimiterService) GetAllResourceDelimiters() (*[]models.ResourceDelimiter, error) {
+func (s *ResourceDelimiterService) GetAllResourceDelimiters(ctx context.Context) (*[]models.ResourceDelimiter, error) {
 	var response []models.ResourceDelimiter
-	err := s.baseService.DoGet("GetAllResourceDelimiters", nil, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetAllResourceDelimiters, nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -35,14 +44,15 @@ func (s *ResourceDelimiterService) GetAllResourceDelimiters() (*[]models.Resourc
 // GetResourceDelimiter retrieves a resource delimiter based on the provided ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - id: A string representing the ID of the resource delimiter.
 //
 // Returns:
 //   - A pointer to models.ResourceDelimiter containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceDelimiterService) GetResourceDelimiter(id string) (*models.ResourceDelimiter, error) {
+func (s *ResourceDelimiterService) GetResourceDelimiter(ctx context.Context, id string) (*models.ResourceDelimiter, error) {
 	var response models.ResourceDelimiter
-	err := s.baseService.DoGet("GetResourceDelimiter", map[string]string{"id": id}, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetResourceDelimiter, map[string]string{"id": id}, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -53,17 +63,92 @@ func (s *ResourceDelimiterService) GetResourceDelimiter(id string) (*models.Reso
 // CreateOrUpdateResourceDelimiter creates or updates a resource delimiter based on the provided request data.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - request: An instance of models.ResourceDelimiter containing the request data.
 //
 // Returns:
-//   - A pointer to models.ResourceDelimiter containing the response data.
+//   - A pointer to models.ResourceDelimiter containing the response data, without ID if the
+//     Naming Tool did not answer with the resource delimiter.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceDelimiterService) CreateOrUpdateResourceDelimiter(request models.ResourceDelimiter) (*models.ResourceDelimiter, error) {
-	var response models.ResourceDelimiter
-	err := s.baseService.DoPost("CreateOrUpdateResourceDelimiter", request, &response)
+func (s *ResourceDelimiterService) CreateOrUpdateResourceDelimiter(ctx context.Context, request models.ResourceDelimiter) (*models.ResourceDelimiter, error) {
+	return postWrite[models.ResourceDelimiter](ctx, s.baseService, EndpointCreateOrUpdateResourceDelimiter, request)
+}
+
+// DefaultResourceDelimiters returns the delimiters the Naming Tool is installed with.
+//
+// Returns:
+//   - A slice of models.ResourceDelimiter with the default delimiters, of which only the dash is enabled.
+func DefaultResourceDelimiters() []models.ResourceDelimiter {
+	return []models.ResourceDelimiter{
+		{Id: 1, Name: "dash", Delimiter: "-", Enabled: true, SortOrder: 1},
+		{Id: 2, Name: "underscore", Delimiter: "_", Enabled: false, SortOrder: 2},
+		{Id: 3, Name: "period", Delimiter: ".", Enabled: false, SortOrder: 3},
+		{Id: 4, Name: "none", Delimiter: "", Enabled: false, SortOrder: 4},
+	}
+}
+
+// ApplyResourceDelimiters makes the delimiters of the Naming Tool match desired, sending only
+// the delimiters that differ. Delimiters are matched by name, ignoring case, and those missing
+// from the Naming Tool are created. Since the Naming Tool cannot delete delimiters, the
+// delimiters missing from desired are disabled instead. Disabled delimiters are sent before
+// enabled ones, so that the Naming Tool ends up with the desired delimiters enabled.
+//
+// Parameters:
+//   - ctx: The context used to cancel the requests.
+//   - desired: The complete list of delimiters. Their IDs are ignored.
+//
+// Returns:
+//   - A slice of models.ResourceDelimiter with all the delimiters of the Naming Tool after the updates.
+//   - An error if desired contains a name twice or a request fails.
+func (s *ResourceDelimiterService) ApplyResourceDelimiters(ctx context.Context, desired []models.ResourceDelimiter) ([]models.ResourceDelimiter, error) {
+	current, err := s.GetAllResourceDelimiters(ctx)
 	if err != nil {
 		return nil, err
 	}
+	existing := make(map[string]models.ResourceDelimiter, len(*current))
+	for _, delimiter := range *current {
+		existing[strings.ToLower(delimiter.Name)] = delimiter
+	}
 
-	return &response, nil
+	var disable, enable []models.ResourceDelimiter
+	wanted := make(map[string]bool, len(desired))
+	for _, delimiter := range desired {
+		key := strings.ToLower(delimiter.Name)
+		if wanted[key] {
+			return nil, fmt.Errorf("delimiter %q is listed more than once", delimiter.Name)
+		}
+		wanted[key] = true
+
+		old, ok := existing[key]
+		delimiter.Id = old.Id
+		if ok && old == delimiter {
+			continue
+		}
+		if bool(delimiter.Enabled) {
+			enable = append(enable, delimiter)
+		} else {
+			disable = append(disable, delimiter)
+		}
+	}
+	for _, delimiter := range *current {
+		if !wanted[strings.ToLower(delimiter.Name)] && bool(delimiter.Enabled) {
+			delimiter.Enabled = false
+			disable = append(disable, delimiter)
+		}
+	}
+
+	for _, delimiter := range append(disable, enable...) {
+		if _, err := s.CreateOrUpdateResourceDelimiter(ctx, delimiter); err != nil {
+			return nil, fmt.Errorf("failed to update delimiter %q: %w", delimiter.Name, err)
+		}
+	}
+	if len(disable)+len(enable) == 0 {
+		return *current, nil
+	}
+
+	updated, err := s.GetAllResourceDelimiters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return *updated, nil
 }
@@ -0,0 +1,63 @@
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDelimiterServer starts a Naming Tool with the default delimiters and returns it with a client of it.
+func newDelimiterServer(t *testing.T) (*namingtooltest.Server, *APIClient) {
+	server := namingtooltest.NewServer(t)
+	for _, delimiter := range DefaultResourceDelimiters() {
+		server.Put(namingtooltest.Delimiters, delimiter)
+	}
+	return server, newTestClient(t, server.URL, server.Client())
+}
+
+func TestApplyResourceDelimiters(t *testing.T) {
+	server, client := newDelimiterServer(t)
+	service := NewResourceDelimiterService(client)
+
+	desired := []models.ResourceDelimiter{
+		{Name: "Underscore", Delimiter: "_", Enabled: true, SortOrder: 1},
+		{Name: "dash", Delimiter: "-", Enabled: false, SortOrder: 2},
+		{Name: "period", Delimiter: ".", Enabled: false, SortOrder: 3},
+		{Name: "tilde", Delimiter: "~", Enabled: false, SortOrder: 4},
+	}
+	delimiters, err := service.ApplyResourceDelimiters(context.Background(), desired)
+	assert.NoError(t, err)
+	assert.Len(t, delimiters, 5)
+
+	// The unchanged period is not sent, and the enabled delimiter is sent last
+	posted := namingtooltest.Posted[models.ResourceDelimiter](server, namingtooltest.Delimiters)
+	if assert.Len(t, posted, 3) {
+		assert.Equal(t, models.ResourceDelimiter{Id: 1, Name: "dash", Delimiter: "-", Enabled: false, SortOrder: 2}, posted[0])
+		assert.Equal(t, models.ResourceDelimiter{Id: 5, Name: "tilde", Delimiter: "~", Enabled: false, SortOrder: 4}, posted[1])
+		assert.Equal(t, models.ResourceDelimiter{Id: 2, Name: "Underscore", Delimiter: "_", Enabled: true, SortOrder: 1}, posted[2])
+	}
+
+	// Applying the same delimiters again sends nothing
+	_, err = service.ApplyResourceDelimiters(context.Background(), desired)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, server.Writes(namingtooltest.Delimiters))
+
+	// Delimiters left out are disabled, since they cannot be deleted
+	delimiters, err = service.ApplyResourceDelimiters(context.Background(), DefaultResourceDelimiters())
+	assert.NoError(t, err)
+	assert.Contains(t, delimiters, models.ResourceDelimiter{Id: 5, Name: "tilde", Delimiter: "~", Enabled: false, SortOrder: 4})
+	assert.Contains(t, delimiters, DefaultResourceDelimiters()[0])
+}
+
+func TestApplyResourceDelimitersRejectsDuplicates(t *testing.T) {
+	server, client := newDelimiterServer(t)
+	_, err := NewResourceDelimiterService(client).ApplyResourceDelimiters(context.Background(), []models.ResourceDelimiter{
+		{Name: "dash", Delimiter: "-", Enabled: true, SortOrder: 1},
+		{Name: "Dash", Delimiter: "-", Enabled: false, SortOrder: 2},
+	})
+	assert.ErrorContains(t, err, `"Dash" is listed more than once`)
+	assert.Zero(t, server.Writes(namingtooltest.Delimiters))
+}
@@ -1,9 +1,11 @@
 package apiclient
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
 )
 
 // ResourceNamingService provides methods for requesting and validating resource names.
@@ -25,14 +27,15 @@ func NewResourceNamingService(client *APIClient) *ResourceNamingService {
 // RequestName requests a new resource name based on the provided request data.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - request: An instance of models.ResourceNameRequest containing the request data.
 //
 // Returns:
 //   - A pointer to models.ResourceNameResponse containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceNamingService) RequestName(request *models.ResourceNameRequest) (*models.ResourceNameResponse, error) {
+func (s *ResourceNamingService) RequestName(ctx context.Context, request *models.ResourceNameRequest) (*models.ResourceNameResponse, error) {
 	var response models.ResourceNameResponse
-	err := s.baseService.DoPost("RequestName", request, &response)
+	err := s.baseService.DoPost(ctx, EndpointRequestName, request, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -46,14 +49,15 @@ func (s *ResourceNamingService) RequestName(request *models.ResourceNameRequest)
 // RequestNameWithComponents requests a new resource name with components based on the provided request data.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - request: An instance of models.ResourceNameRequestWithComponents containing the request data.
 //
 // Returns:
 //   - A pointer to models.ResourceNameResponse containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceNamingService) RequestNameWithComponents(request models.ResourceNameRequestWithComponents) (*models.ResourceNameResponse, error) {
+func (s *ResourceNamingService) RequestNameWithComponents(ctx context.Context, request models.ResourceNameRequestWithComponents) (*models.ResourceNameResponse, error) {
 	var response models.ResourceNameResponse
-	err := s.baseService.DoPost("RequestName", request, &response)
+	err := s.baseService.DoPost(ctx, EndpointRequestNameWithComponents, request, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -68,14 +72,15 @@ func (s *ResourceNamingService) RequestNameWithComponents(request models.Resourc
 // ValidatetName validates a resource name based on the provided request data.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - request: An instance of models.ValidateNameRequest containing the request data.
 //
 // Returns:
 //   - A pointer to models.ValidateNameResponse containing the validation response.
 //   - An error if the request fails.
-func (s *ResourceNamingService) ValidatetName(request models.ValidateNameRequest) (*models.ValidateNameResponse, error) {
+func (s *ResourceNamingService) ValidatetName(ctx context.Context, request models.ValidateNameRequest) (*models.ValidateNameResponse, error) {
 	var response models.ValidateNameResponse
-	err := s.baseService.DoPost("ValidateName", request, &response)
+	err := s.baseService.DoPost(ctx, EndpointValidateName, request, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -83,33 +88,57 @@ func (s *ResourceNamingService) ValidatetName(request models.ValidateNameRequest
 	return &response, nil
 }
 
+// ListGeneratedNames retrieves the generated names log of the Naming Tool.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//
+// Returns:
+//   - A slice containing the generated names.
+//   - An error if the request fails.
+func (s *ResourceNamingService) ListGeneratedNames(ctx context.Context) ([]models.ResourceGeneratedName, error) {
+	var response []models.ResourceGeneratedName
+	err := s.baseService.DoGet(ctx, EndpointGetAllGeneratedNames, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetGeneratedName retrieves a generated resource name by its ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - id: A string representing the ID of the generated resource name.
 //
 // Returns:
 //   - A pointer to models.ResourceGeneratedName containing the generated name data.
-//   - An error if the request fails.
-func (s *ResourceNamingService) GetGeneratedName(id string) (*models.ResourceGeneratedName, error) {
+//   - An error if the request fails, matching utils.ErrNotFound if the name does not exist.
+func (s *ResourceNamingService) GetGeneratedName(ctx context.Context, id string) (*models.ResourceGeneratedName, error) {
 	var response models.ResourceGeneratedName
-	err := s.baseService.DoGet("GetGeneratedName", map[string]string{"id": id}, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetGeneratedName, map[string]string{"id": id}, &response)
 	if err != nil {
 		return nil, err
 	}
 
+	// The Naming Tool answers with an empty record for unknown IDs.
+	if response.Id == 0 {
+		return nil, fmt.Errorf("%w: generated name %s", utils.ErrNotFound, id)
+	}
+
 	return &response, nil
 }
 
 // DeleteGeneratedName deletes a generated resource name by its ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - id: A string representing the ID of the generated resource name.
 //
 // Returns:
 //   - An error if the request fails.
-func (s *ResourceNamingService) DeleteGeneratedName(id string) error {
-	err := s.baseService.DoDelete("DeleteGeneratedName", map[string]string{"id": id})
+func (s *ResourceNamingService) DeleteGeneratedName(ctx context.Context, id string) error {
+	err := s.baseService.DoDelete(ctx, EndpointDeleteGeneratedName, map[string]string{"id": id})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,39 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGeneratedNameNotFound(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"not found status", http.StatusNotFound, `"Name not found"`},
+		{"empty record", http.StatusOK, `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server.URL, server.Client())
+			service := NewResourceNamingService(client)
+
+			_, err := service.GetGeneratedName(context.Background(), "42")
+
+			assert.ErrorIs(t, err, utils.ErrNotFound)
+		})
+	}
+}
@@ -1,6 +1,14 @@
 package apiclient
 
-import "github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
 
 type ResourceTypeService struct {
 	baseService *BaseService
@@ -19,12 +27,15 @@ func NewResourceTypeService(client *APIClient) *ResourceTypeService {
 
 // GetAllResourceTypes retrieves all resource types.
 //
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//
 // Returns:
 //   - A pointer to a slice of models.ResourceType containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceTypeService) GetAllResourceTypes() (*[]models.ResourceType, error) {
+func (s *ResourceTypeService) GetAllResourceTypes(ctx context.Context) (*[]models.ResourceType, error) {
 	var response []models.ResourceType
-	err := s.baseService.DoGet("GetAllResourceTypes", nil, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetAllResourceTypes, nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -35,17 +46,81 @@ func (s *ResourceTypeService) GetAllResourceTypes() (*[]models.ResourceType, err
 // GetResourceType retrieves a resource type based on the provided ID.
 //
 // Parameters:
+//   - ctx: The context used to cancel the request.
 //   - id: A string representing the ID of the resource type.
 //
 // Returns:
 //   - A pointer to models.ResourceType containing the response data.
 //   - An error if the request fails or the response indicates failure.
-func (s *ResourceTypeService) GetResourceType(id string) (*models.ResourceType, error) {
+func (s *ResourceTypeService) GetResourceType(ctx context.Context, id string) (*models.ResourceType, error) {
 	var response models.ResourceType
-	err := s.baseService.DoGet("GetResourceType", map[string]string{"id": id}, &response)
+	err := s.baseService.DoGet(ctx, EndpointGetResourceType, map[string]string{"id": id}, &response)
 	if err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
+
+// FindResourceTypeByResource returns the resource type whose resource matches resource, ignoring case.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - resource: The resource of the type, such as "Storage/storageAccounts".
+//
+// Returns:
+//   - A pointer to models.ResourceType.
+//   - An error matching utils.ErrNotFound if no type has the resource, or an error if the request fails.
+func (s *ResourceTypeService) FindResourceTypeByResource(ctx context.Context, resource string) (*models.ResourceType, error) {
+	resourceTypes, err := s.GetAllResourceTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *resourceTypes {
+		if strings.EqualFold((*resourceTypes)[i].Resource, resource) {
+			return &(*resourceTypes)[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: resource type %q", utils.ErrNotFound, resource)
+}
+
+// UpdateResourceType replaces the resource type with the ID of resourceType. The Naming Tool
+// only accepts the complete configuration of the resource types, so all of them are read,
+// bypassing the cache, and sent back. Concurrent updates through the client are serialized,
+// so that they do not overwrite each other's changes.
+//
+// Parameters:
+//   - ctx: The context used to cancel the requests.
+//   - resourceType: The resource type to update, with its ID.
+//
+// Returns:
+//   - A pointer to models.ResourceType as returned by the Naming Tool after the update.
+//   - An error matching utils.ErrNotFound if the resource type does not exist, or an error if a request fails.
+func (s *ResourceTypeService) UpdateResourceType(ctx context.Context, resourceType models.ResourceType) (*models.ResourceType, error) {
+	client := s.baseService.client
+	client.resourceTypesMu.Lock()
+	defer client.resourceTypesMu.Unlock()
+
+	uncached := EndpointGetAllResourceTypes
+	uncached.Cacheable = false
+	var config []models.ResourceType
+	if err := s.baseService.DoGet(ctx, uncached, nil, &config); err != nil {
+		return nil, err
+	}
+
+	found := false
+	for i := range config {
+		if config[i].Id == resourceType.Id {
+			config[i] = resourceType
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: resource type %d", utils.ErrNotFound, resourceType.Id)
+	}
+
+	if err := s.baseService.DoPost(ctx, EndpointPostResourceTypesConfig, config, nil); err != nil {
+		return nil, err
+	}
+	return s.GetResourceType(ctx, strconv.FormatInt(resourceType.Id, 10))
+}
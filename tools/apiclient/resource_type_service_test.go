@@ -0,0 +1,92 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/internal/namingtooltest"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateResourceType(t *testing.T) {
+	server := namingtooltest.NewServer(t)
+	server.Put(namingtooltest.ResourceTypes,
+		models.ResourceType{Id: 1, Resource: "KeyVault/vaults", ShortName: "kv", Enabled: true, ApplyDelimiter: true},
+		models.ResourceType{Id: 2, Resource: "Storage/storageAccounts", ShortName: "st", Enabled: true, ApplyDelimiter: true},
+	)
+
+	client := newTestClient(t, server.URL, server.Client(), WithCache(time.Hour))
+	service := NewResourceTypeService(client)
+
+	storage, err := service.FindResourceTypeByResource(context.Background(), "storage/storageaccounts")
+	if !assert.NoError(t, err) {
+		return
+	}
+	storage.ApplyDelimiter = false
+	storage.SetExcludedComponents([]string{"UnitDept"})
+
+	// The whole configuration is sent, and the cached resource types are not returned
+	updated, err := service.UpdateResourceType(context.Background(), *storage)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, server.Writes(namingtooltest.ResourceTypes))
+	resourceTypes := namingtooltest.Records[models.ResourceType](server, namingtooltest.ResourceTypes)
+	assert.Len(t, resourceTypes, 2)
+	assert.Equal(t, "kv", resourceTypes[0].ShortName)
+	assert.False(t, bool(updated.ApplyDelimiter))
+	assert.Equal(t, []string{"UnitDept"}, updated.ExcludedComponents())
+
+	_, err = service.UpdateResourceType(context.Background(), models.ResourceType{Id: 7})
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestUpdateResourceTypeConcurrently(t *testing.T) {
+	server := namingtooltest.NewServer(t)
+	server.Put(namingtooltest.ResourceTypes,
+		models.ResourceType{Id: 1, Resource: "KeyVault/vaults", ShortName: "kv", Enabled: true},
+		models.ResourceType{Id: 2, Resource: "Storage/storageAccounts", ShortName: "st", Enabled: true},
+		models.ResourceType{Id: 3, Resource: "Web/sites", ShortName: "app", Enabled: true},
+	)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A slow list leaves room for the other update to read the same configuration
+		if r.Method == http.MethodGet && r.URL.Path == namingtooltest.ResourceTypes {
+			time.Sleep(20 * time.Millisecond)
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer slow.Close()
+
+	client := newTestClient(t, slow.URL, slow.Client(), WithCache(time.Hour))
+	service := NewResourceTypeService(client)
+	cached, err := service.GetAllResourceTypes(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// A change made in the Naming Tool after the resource types were cached
+	server.Put(namingtooltest.ResourceTypes, models.ResourceType{Id: 3, Resource: "Web/sites", ShortName: "app", Enabled: false})
+
+	var wg sync.WaitGroup
+	for i, shortName := range []string{"vault", "sa"} {
+		resourceType := (*cached)[i]
+		resourceType.ShortName = shortName
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.UpdateResourceType(context.Background(), resourceType)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Neither update reverts the other one or the change made outside the client
+	resourceTypes := namingtooltest.Records[models.ResourceType](server, namingtooltest.ResourceTypes)
+	assert.Equal(t, "vault", resourceTypes[0].ShortName)
+	assert.Equal(t, "sa", resourceTypes[1].ShortName)
+	assert.False(t, bool(resourceTypes[2].Enabled))
+}
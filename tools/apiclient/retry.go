@@ -0,0 +1,128 @@
+package apiclient
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how BaseService retries failed requests.
+type RetryPolicy struct {
+	MaxAttempts          int           // The total number of attempts, including the first one. Values below 2 disable retries.
+	MinWait              time.Duration // The wait before the first retry.
+	MaxWait              time.Duration // The upper bound for any single wait, including Retry-After.
+	Jitter               bool          // Randomises each wait to spread out concurrent retries.
+	RetryableStatusCodes []int         // The response status codes that are retried.
+	RetryTransportErrors bool          // Retries requests that failed before a response was received.
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+//
+// Returns:
+//   - A RetryPolicy retrying throttling and gateway errors up to three times.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          3,
+		MinWait:              1 * time.Second,
+		MaxWait:              30 * time.Second,
+		Jitter:               true,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		RetryTransportErrors: true,
+	}
+}
+
+// shouldRetry reports whether an attempt that produced resp or err may be sent again.
+//
+// Requests that are not idempotent, such as the POST behind RequestName, are only
+// retried when the server cannot have processed them: a 429 response or a failure
+// to establish the connection. Anything else could mint a second name.
+func (p RetryPolicy) shouldRetry(method string, resp *http.Response, err error) bool {
+	idempotent := isIdempotent(method)
+
+	if err != nil {
+		if !p.RetryTransportErrors {
+			return false
+		}
+		return idempotent || isDialError(err)
+	}
+
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	if !idempotent && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the given retry, starting at 1 for the first retry.
+// A Retry-After header on resp takes precedence over the computed exponential wait.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	wait := p.MinWait
+	for i := 1; i < retry && wait < p.MaxWait; i++ {
+		wait *= 2
+	}
+
+	if p.Jitter && wait > 0 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	if retryAfter, ok := parseRetryAfter(resp); ok && retryAfter > wait {
+		wait = retryAfter
+	}
+
+	if p.MaxWait > 0 && wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	return wait
+}
+
+// isIdempotent reports whether a request with the given method can be repeated safely.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isDialError reports whether err happened while establishing the connection,
+// in which case the request never reached the server.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// parseRetryAfter reads the Retry-After header, which holds either seconds or an HTTP date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,92 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRetryTestClient(t *testing.T, serverURL string, httpClient *http.Client) *APIClient {
+	client := newTestClient(t, serverURL, httpClient)
+	client.RetryPolicy.MinWait = time.Millisecond
+	client.RetryPolicy.MaxWait = 10 * time.Millisecond
+	return client
+}
+
+func TestRetryGetOnServiceUnavailable(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newRetryTestClient(t, server.URL, server.Client()))
+
+	var response []models.ResourceType
+	err := service.DoGet(context.Background(), EndpointGetAllResourceTypes, nil, &response)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPostNotRepeatedAfterServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newRetryTestClient(t, server.URL, server.Client()))
+
+	var response models.ResourceNameResponse
+	err := service.DoPost(context.Background(), EndpointRequestName, models.ResourceNameRequest{}, &response)
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPostHonoursRetryAfterOnThrottling(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"Success": true}`))
+	}))
+	defer server.Close()
+
+	service := NewBaseService(newRetryTestClient(t, server.URL, server.Client()))
+
+	var response models.ResourceNameResponse
+	err := service.DoPost(context.Background(), EndpointRequestName, models.ResourceNameRequest{}, &response)
+
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryBackoffIsBounded(t *testing.T) {
+	policy := RetryPolicy{MinWait: time.Second, MaxWait: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.backoff(1, nil))
+	assert.Equal(t, 4*time.Second, policy.backoff(3, nil))
+	assert.Equal(t, 5*time.Second, policy.backoff(10, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	assert.Equal(t, 5*time.Second, policy.backoff(1, resp))
+}
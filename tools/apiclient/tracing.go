@@ -0,0 +1,91 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans created by the client.
+const tracerName = "github.com/rafaelherik/terraform-provider-aznamingtool/tools/apiclient"
+
+// traceContext propagates the span of every request to the Naming Tool in the W3C
+// traceparent and tracestate headers.
+var traceContext = propagation.TraceContext{}
+
+// WithTracerProvider sets the provider of the tracer creating a span for every request sent
+// to the Naming Tool. By default the global OpenTelemetry tracer provider is used.
+func WithTracerProvider(provider trace.TracerProvider) ClientOption {
+	return func(c *APIClient) {
+		c.TracerProvider = provider
+	}
+}
+
+// tracer returns the tracer of the client's TracerProvider, or of the global provider if it is not set.
+func (c *APIClient) tracer() trace.Tracer {
+	provider := c.TracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// startRequestSpan starts the client span of one attempt of req and injects its context
+// into the request headers. The span is named after the endpoint path template, so that
+// requests for different IDs share a name.
+func (c *APIClient) startRequestSpan(ctx context.Context, req *http.Request, attempt int) (context.Context, trace.Span) {
+	name := req.Method
+	attributes := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(redactURL(req.URL)),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		attributes = append(attributes, semconv.ServerPort(port))
+	}
+	if attempt > 1 {
+		attributes = append(attributes, semconv.HTTPRequestResendCount(attempt-1))
+	}
+	if endpoint, ok := EndpointFromContext(ctx); ok {
+		name += " " + endpoint.Path
+		attributes = append(attributes, attribute.String("naming_tool.endpoint", endpoint.Name))
+	}
+
+	ctx, span := c.tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	traceContext.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return ctx, span
+}
+
+// endRequestSpan records the outcome of the request on span and ends it. Status codes of
+// 400 and above mark the span as failed, as for every HTTP client span.
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case resp.StatusCode >= 400:
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode), semconv.ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)))
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	default:
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+	span.End()
+}
+
+// redactURL returns u without user information, which must not be exported with the spans.
+func redactURL(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+	redacted := *u
+	redacted.User = nil
+	return redacted.String()
+}
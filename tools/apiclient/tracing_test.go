@@ -0,0 +1,117 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracerProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return provider, recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestRequestSpans(t *testing.T) {
+	var mu sync.Mutex
+	var traceparents []string
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		mu.Unlock()
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 7, "name": "Virtual Machine"}`))
+	}))
+	defer server.Close()
+
+	provider, recorder := newTestTracerProvider(t)
+	client := newTestClient(t, server.URL, server.Client(), WithTracerProvider(provider))
+	client.RetryPolicy.MinWait = time.Millisecond
+	client.RetryPolicy.Jitter = false
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "aznamingtool_resource_name.Read")
+	var response map[string]interface{}
+	assert.NoError(t, NewBaseService(client).DoGet(ctx, EndpointGetResourceType, idParams(7), &response))
+	parent.End()
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 3) {
+		return
+	}
+	failed, succeeded := spans[0], spans[1]
+
+	for i, span := range []sdktrace.ReadOnlySpan{failed, succeeded} {
+		assert.Equal(t, "GET /api/ResourceTypes/{id}", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		// The Naming Tool receives the context of the attempt span
+		assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", traceparents[i])
+
+		endpoint, _ := spanAttribute(span, "naming_tool.endpoint")
+		assert.Equal(t, "GetResourceType", endpoint.AsString())
+		url, _ := spanAttribute(span, "url.full")
+		assert.Equal(t, server.URL+"/api/ResourceTypes/7", url.AsString())
+	}
+
+	status, _ := spanAttribute(failed, "http.response.status_code")
+	assert.Equal(t, int64(http.StatusServiceUnavailable), status.AsInt64())
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	_, resent := spanAttribute(failed, "http.request.resend_count")
+	assert.False(t, resent)
+
+	status, _ = spanAttribute(succeeded, "http.response.status_code")
+	assert.Equal(t, int64(http.StatusOK), status.AsInt64())
+	assert.Equal(t, codes.Unset, succeeded.Status().Code)
+	resendCount, _ := spanAttribute(succeeded, "http.request.resend_count")
+	assert.Equal(t, int64(1), resendCount.AsInt64())
+
+	events := succeeded.Events()
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "dequeued", events[0].Name)
+	}
+}
+
+func TestRequestSpanTransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	provider, recorder := newTestTracerProvider(t)
+	client := newTestClient(t, server.URL, nil, WithTracerProvider(provider))
+	client.RetryPolicy.MaxAttempts = 1
+
+	var response map[string]interface{}
+	assert.Error(t, NewBaseService(client).DoGet(context.Background(), EndpointGetResourceType, idParams(7), &response))
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.False(t, spans[0].Parent().IsValid())
+		_, hasStatus := spanAttribute(spans[0], "http.response.status_code")
+		assert.False(t, hasStatus)
+	}
+}
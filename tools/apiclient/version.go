@@ -0,0 +1,194 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+)
+
+// ServerVersion is the release of the Naming Tool the client talks to.
+type ServerVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+var (
+	// MinServerVersion is the oldest Naming Tool release the client supports.
+	MinServerVersion = ServerVersion{Major: 4, Minor: 2, Patch: 0}
+	// MaxTestedServerVersion is the newest Naming Tool release the client was tested with.
+	MaxTestedServerVersion = ServerVersion{Major: 4, Minor: 2, Patch: 1}
+)
+
+// ParseServerVersion parses a version such as "4.2.1", "v4.2.1" or the assembly version
+// "4.2.1.0". Missing minor and patch numbers are read as zero, and the revision number,
+// pre-release and build suffixes are ignored.
+//
+// Returns:
+//   - The parsed version.
+//   - An error if the major, minor or patch number is not a number.
+func ParseServerVersion(value string) (ServerVersion, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if i := strings.IndexAny(trimmed, "-+ "); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) > 4 {
+		return ServerVersion{}, fmt.Errorf("invalid Naming Tool version %q", value)
+	}
+
+	numbers := make([]int, 3)
+	for i := 0; i < len(parts) && i < 3; i++ {
+		number, err := strconv.Atoi(parts[i])
+		if err != nil || number < 0 {
+			return ServerVersion{}, fmt.Errorf("invalid Naming Tool version %q", value)
+		}
+		numbers[i] = number
+	}
+	return ServerVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// String returns the version as major.minor.patch.
+func (v ServerVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 if v is older than, the same as or newer than other.
+func (v ServerVersion) Compare(other ServerVersion) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// IsZero reports whether the version is unknown.
+func (v ServerVersion) IsZero() bool {
+	return v == ServerVersion{}
+}
+
+// Supported reports whether the client supports the version.
+func (v ServerVersion) Supported() bool {
+	return v.Compare(MinServerVersion) >= 0
+}
+
+// Tested reports whether the client was tested with the version.
+func (v ServerVersion) Tested() bool {
+	return v.Supported() && v.Compare(MaxTestedServerVersion) <= 0
+}
+
+// EndpointVariant is the path of an endpoint from a release of the Naming Tool onwards.
+type EndpointVariant struct {
+	Since ServerVersion // The first release serving the endpoint at Path.
+	Path  string        // The path of the endpoint, with the same placeholders as the endpoint.
+}
+
+// forVersion returns the endpoint with the path of the newest variant available in version.
+// The endpoint is returned unchanged when the version is unknown or older than every variant.
+func (e Endpoint) forVersion(version ServerVersion) Endpoint {
+	if version.IsZero() {
+		return e
+	}
+	var selected *EndpointVariant
+	for i, variant := range e.Variants {
+		if variant.Since.Compare(version) <= 0 && (selected == nil || variant.Since.Compare(selected.Since) > 0) {
+			selected = &e.Variants[i]
+		}
+	}
+	if selected != nil {
+		e.Path = selected.Path
+	}
+	return e
+}
+
+// WithServerVersion sets the Naming Tool version instead of detecting it, for servers
+// that do not report their version.
+func WithServerVersion(version ServerVersion) ClientOption {
+	return func(c *APIClient) {
+		c.ServerVersion = version
+	}
+}
+
+// DetectServerVersion asks the Naming Tool for its version and uses it to choose the
+// endpoint variants of later requests. The version is only requested once, and a Naming
+// Tool that does not report it is not asked again.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//
+// Returns:
+//   - The version of the Naming Tool.
+//   - An error matching utils.ErrNotFound if the Naming Tool does not report its version,
+//     or an error if the request fails or the version cannot be parsed.
+func (c *APIClient) DetectServerVersion(ctx context.Context) (ServerVersion, error) {
+	c.mu.RLock()
+	version, unreported := c.ServerVersion, c.versionUnreported
+	c.mu.RUnlock()
+	if !version.IsZero() {
+		return version, nil
+	}
+	if unreported {
+		return ServerVersion{}, fmt.Errorf("%w: the Naming Tool does not report its version", utils.ErrNotFound)
+	}
+
+	var response json.RawMessage
+	err := NewBaseService(c).DoGet(ctx, EndpointGetServerVersion, nil, &response)
+	var raw string
+	if err == nil {
+		raw, err = decodeServerVersion(response)
+	}
+	if errors.Is(err, utils.ErrNotFound) {
+		c.mu.Lock()
+		c.versionUnreported = true
+		c.mu.Unlock()
+	}
+	if err != nil {
+		return ServerVersion{}, err
+	}
+	version, err = ParseServerVersion(raw)
+	if err != nil {
+		return ServerVersion{}, err
+	}
+
+	c.mu.Lock()
+	c.ServerVersion = version
+	c.mu.Unlock()
+	return version, nil
+}
+
+// Version returns the Naming Tool version set or detected, or a zero version if it is unknown.
+func (c *APIClient) Version() ServerVersion {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ServerVersion
+}
+
+// decodeServerVersion reads the version from a JSON string or from the version property of
+// a JSON object.
+func decodeServerVersion(body json.RawMessage) (string, error) {
+	var version string
+	if json.Unmarshal(body, &version) == nil {
+		return version, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return "", fmt.Errorf("failed to decode Naming Tool version: %w", err)
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, "version") && json.Unmarshal(value, &version) == nil {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("%w: the Naming Tool response has no version", utils.ErrNotFound)
+}
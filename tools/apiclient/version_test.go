@@ -0,0 +1,117 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafaelherik/terraform-provider-aznamingtool/tools/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected ServerVersion
+		valid    bool
+	}{
+		{value: "4.2.1", expected: ServerVersion{4, 2, 1}, valid: true},
+		{value: "v4.2.0", expected: ServerVersion{4, 2, 0}, valid: true},
+		{value: "4.2.1.0", expected: ServerVersion{4, 2, 1}, valid: true},
+		{value: "5.0.0-beta+abc", expected: ServerVersion{5, 0, 0}, valid: true},
+		{value: "4", expected: ServerVersion{4, 0, 0}, valid: true},
+		{value: "four", valid: false},
+		{value: "4.x", valid: false},
+		{value: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			version, err := ParseServerVersion(tt.value)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
+
+func TestServerVersionSupport(t *testing.T) {
+	assert.False(t, ServerVersion{4, 1, 9}.Supported())
+	assert.True(t, ServerVersion{4, 2, 0}.Supported())
+	assert.True(t, ServerVersion{4, 2, 1}.Tested())
+	assert.True(t, ServerVersion{4, 3, 0}.Supported())
+	assert.False(t, ServerVersion{4, 3, 0}.Tested())
+}
+
+func TestEndpointForVersion(t *testing.T) {
+	endpoint := Endpoint{
+		Name:   "GetThing",
+		Method: http.MethodGet,
+		Path:   "/api/Things/{id}",
+		Params: []string{"id"},
+		Variants: []EndpointVariant{
+			{Since: ServerVersion{5, 0, 0}, Path: "/api/v5/Things/{id}"},
+			{Since: ServerVersion{4, 2, 1}, Path: "/api/v2/Things/{id}"},
+		},
+	}
+
+	assert.Equal(t, "/api/Things/{id}", endpoint.forVersion(ServerVersion{}).Path)
+	assert.Equal(t, "/api/Things/{id}", endpoint.forVersion(ServerVersion{4, 2, 0}).Path)
+	assert.Equal(t, "/api/v2/Things/{id}", endpoint.forVersion(ServerVersion{4, 2, 1}).Path)
+	assert.Equal(t, "/api/v5/Things/{id}", endpoint.forVersion(ServerVersion{5, 1, 0}).Path)
+}
+
+func TestDetectServerVersion(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, "/api/Admin/GetVersion", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"Version": "v4.2.1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, server.Client())
+
+	for i := 0; i < 2; i++ {
+		version, err := client.DetectServerVersion(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, ServerVersion{4, 2, 1}, version)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestDetectServerVersionNotReported(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// Even when 404 is retryable, the Naming Tool is asked only once
+	client := newTestClient(t, server.URL, server.Client())
+	client.RetryPolicy.MinWait = time.Millisecond
+	client.RetryPolicy.RetryableStatusCodes = []int{http.StatusNotFound}
+
+	for i := 0; i < 2; i++ {
+		_, err := client.DetectServerVersion(context.Background())
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	}
+	assert.True(t, client.Version().IsZero())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestConfiguredServerVersionSkipsDetection(t *testing.T) {
+	client := newTestClient(t, "http://127.0.0.1:1", nil, WithServerVersion(ServerVersion{4, 2, 0}))
+
+	version, err := client.DetectServerVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ServerVersion{4, 2, 0}, version)
+}
@@ -7,6 +7,19 @@ import (
 
 var (
 	ErrorClientNotInitialized = constError("client not initialized")
+	ErrQueueFull              = constError("request queue is full")
+	ErrClientClosed           = constError("client is closed")
+	ErrInvalidBaseURL         = constError("invalid base URL")
+	ErrMissingAPIKey          = constError("missing API key")
+	ErrInvalidEndpoint        = constError("invalid endpoint call")
+	ErrNoRecordedInteraction  = constError("no recorded interaction")
+	ErrInvalidResponse        = constError("invalid response")
+	ErrBadRequest             = constError("bad request")
+	ErrUnauthorized           = constError("unauthorized")
+	ErrNotFound               = constError("not found")
+	ErrConflict               = constError("conflict")
+	ErrTooManyRequests        = constError("too many requests")
+	ErrServerError            = constError("server error")
 )
 
 type constError string